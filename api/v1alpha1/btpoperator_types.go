@@ -20,10 +20,13 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const componentName = "btp-operator"
 const DisableNetworkPoliciesAnnotation = "operator.kyma-project.io/btp-operator-disable-network-policies"
+const ForceDeleteConfirmedAnnotation = "operator.kyma-project.io/force-delete-confirmed"
+const UpgradeApprovedVersionAnnotation = "operator.kyma-project.io/approved-upgrade-version"
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -32,6 +35,7 @@ const DisableNetworkPoliciesAnnotation = "operator.kyma-project.io/btp-operator-
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:categories={kyma-modules,kyma-btp-operator}
 //+kubebuilder:printcolumn:name="State",type=string,JSONPath=".status.state"
+//+kubebuilder:printcolumn:name="Ready Replicas",type=integer,JSONPath=".status.readyReplicas",priority=1
 
 // BtpOperator is the Schema for the btpoperators API
 type BtpOperator struct {
@@ -44,7 +48,76 @@ type BtpOperator struct {
 }
 
 // BtpOperatorSpec defines the desired state of BtpOperator
-type BtpOperatorSpec struct{}
+type BtpOperatorSpec struct {
+	// Replicas is the number of the SAP BTP service operator Pods.
+	// Leader election is enabled in the SAP BTP service operator when more than one replica is requested.
+	// The default number of replicas from the module resources is used when the field is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// PriorityClassName overrides the priority class of the SAP BTP service operator Pods.
+	// The default priority class from the module resources is used when the field is empty.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// PodDisruptionBudget enables a PodDisruptionBudget for the SAP BTP service operator Pods.
+	// No PodDisruptionBudget is created when the field is not set.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// UpgradePolicy controls whether a new version of the module resources is applied automatically.
+	// With the Manual policy, the upgrade waits until it is approved with the approved-upgrade-version annotation.
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// +optional
+	UpgradePolicy UpgradePolicy `json:"upgradePolicy,omitempty"`
+
+	// CommonLabels are added to all module resources applied by BTP Manager.
+	// They don't override the labels that the module resources already have.
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// CommonAnnotations are added to all module resources applied by BTP Manager.
+	// They don't override the annotations that the module resources already have.
+	// +optional
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// NetworkPolicies controls the network policies applied for the SAP BTP service operator Pods.
+	// When the field is not set, the btp-operator-disable-network-policies annotation decides.
+	// +optional
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+}
+
+// NetworkPoliciesSpec defines the network policy settings for the SAP BTP service operator Pods.
+type NetworkPoliciesSpec struct {
+	// Enabled turns the network policies on or off. Network policies are enabled by default.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// UpgradePolicy defines how a new version of the module resources is applied.
+type UpgradePolicy string
+
+const (
+	// UpgradePolicyAutomatic applies a new version of the module resources as soon as it is available.
+	UpgradePolicyAutomatic UpgradePolicy = "Automatic"
+
+	// UpgradePolicyManual applies a new version of the module resources only after it has been approved.
+	UpgradePolicyManual UpgradePolicy = "Manual"
+)
+
+// PodDisruptionBudgetSpec defines the PodDisruptionBudget settings for the SAP BTP service operator Pods.
+// Only one of MinAvailable and MaxUnavailable can be set. If none is set, MaxUnavailable equal to 1 is used, so that a single replica does not block node drains.
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="minAvailable and maxUnavailable are mutually exclusive"
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable is the number or percentage of Pods that must remain available during an eviction.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of Pods that can be unavailable during an eviction.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
 
 type State string
 
@@ -82,6 +155,27 @@ type Status struct {
 
 	// Conditions associated with CustomStatus.
 	Conditions []*metav1.Condition `json:"conditions,omitempty"`
+
+	// ReadyReplicas is the number of ready SAP BTP service operator Pods.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// UpgradeCheck contains the results of the compatibility check of the last module resources upgrade.
+	// +optional
+	UpgradeCheck *UpgradeCheck `json:"upgradeCheck,omitempty"`
+}
+
+// UpgradeCheck contains the results of the compatibility check run before the module resources are upgraded.
+type UpgradeCheck struct {
+	// FromVersion is the version of the module resources installed in the cluster.
+	FromVersion string `json:"fromVersion"`
+
+	// ToVersion is the version of the module resources to be applied.
+	ToVersion string `json:"toVersion"`
+
+	// Findings lists the detected incompatibilities, such as removed CRD versions or fields, and webhook changes.
+	// +optional
+	Findings []string `json:"findings,omitempty"`
 }
 
 func (s *Status) WithState(state State) Status {
@@ -133,6 +227,9 @@ func (o *BtpOperator) IsMsgForGivenReasonEqual(reason, message string) bool {
 }
 
 func (o *BtpOperator) IsNetworkPoliciesDisabled() bool {
+	if o.Spec.NetworkPolicies != nil && o.Spec.NetworkPolicies.Enabled != nil {
+		return !*o.Spec.NetworkPolicies.Enabled
+	}
 	if o.Annotations == nil {
 		return false
 	}
@@ -143,6 +240,23 @@ func (o *BtpOperator) IsNetworkPoliciesDisabled() bool {
 	return strings.ToLower(value) == "true"
 }
 
+func (o *BtpOperator) IsForceDeleteConfirmed() bool {
+	if o.Annotations == nil {
+		return false
+	}
+	return strings.ToLower(o.Annotations[ForceDeleteConfirmedAnnotation]) == "true"
+}
+
+func (o *BtpOperator) IsUpgradeApproved(version string) bool {
+	if o.Spec.UpgradePolicy != UpgradePolicyManual {
+		return true
+	}
+	if o.Annotations == nil {
+		return false
+	}
+	return o.Annotations[UpgradeApprovedVersionAnnotation] == version
+}
+
 //+kubebuilder:object:root=true
 
 // BtpOperatorList contains a list of BtpOperator
@@ -306,9 +306,15 @@ func tryConvertGoLineToStruct(goLine string) (error, *reasonMetadata) {
 		return fmt.Errorf("goLine (%s) is badly structured, it should have following format (Reason: Metadata, //CRState;Remark", goLine), nil
 	}
 
+	// the optional third part of the comment is the type of a condition other than Ready
 	comments := strings.Split(parts[1], ";")
-	if len(comments) != 2 {
-		return fmt.Errorf("comment in goLine (%s) is badly structured, it should have following format (//CRState;Remark)", goLine), nil
+	if len(comments) != 2 && len(comments) != 3 {
+		return fmt.Errorf("comment in goLine (%s) is badly structured, it should have following format (//CRState;Remark[;ConditionType])", goLine), nil
+	}
+	reasonConditionType := conditionType
+	if len(comments) == 3 {
+		reasonConditionType = comments[2]
+		cleanString(&reasonConditionType)
 	}
 
 	reason := words[0]
@@ -323,8 +329,8 @@ func tryConvertGoLineToStruct(goLine string) (error, *reasonMetadata) {
 	return nil, &reasonMetadata{
 		groupOrder:      detectGroupOrder(state),
 		crState:         state,
-		conditionType:   conditionType,
-		conditionStatus: getConditionStatus(state, conditionType),
+		conditionType:   reasonConditionType,
+		conditionStatus: getConditionStatus(state, reasonConditionType),
 		conditionReason: reason,
 		remark:          remark,
 	}
@@ -18,27 +18,36 @@ package controllers
 
 import (
 	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyma-project/btp-manager/api/v1alpha1"
 	"github.com/kyma-project/btp-manager/internal/certs"
 	"github.com/kyma-project/btp-manager/internal/conditions"
+	"github.com/kyma-project/btp-manager/internal/logformat"
 	"github.com/kyma-project/btp-manager/internal/manifest"
 	"github.com/kyma-project/btp-manager/internal/metrics"
 	"github.com/kyma-project/btp-manager/internal/ymlutils"
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -47,7 +56,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8sgenerictypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,29 +67,42 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // Configuration options that can be overwritten either by CLI parameter or ConfigMap
 var (
-	ChartNamespace                 = "kyma-system"
-	SecretName                     = "sap-btp-manager"
-	ConfigName                     = "sap-btp-manager"
-	DeploymentName                 = "sap-btp-operator-controller-manager"
-	ProcessingStateRequeueInterval = time.Minute * 5
-	ReadyStateRequeueInterval      = time.Minute * 15
-	ReadyTimeout                   = time.Minute * 5
-	ReadyCheckInterval             = time.Second * 30
-	HardDeleteTimeout              = time.Minute * 20
-	HardDeleteCheckInterval        = time.Second * 10
-	DeleteRequestTimeout           = time.Minute * 5
-	StatusUpdateTimeout            = time.Second * 10
-	StatusUpdateCheckInterval      = time.Millisecond * 500
-	ChartPath                      = "./module-chart/chart"
-	ResourcesPath                  = "./module-resources"
-	ManagerResourcesPath           = "./manager-resources"
-	EnableLimitedCache             = "false"
+	ChartNamespace                   = "kyma-system"
+	SecretName                       = "sap-btp-manager"
+	ConfigName                       = "sap-btp-manager"
+	DeploymentName                   = "sap-btp-operator-controller-manager"
+	ProcessingStateRequeueInterval   = time.Minute * 5
+	ReadyStateRequeueInterval        = time.Minute * 15
+	ReadyTimeout                     = time.Minute * 5
+	ReadyCheckInterval               = time.Second * 30
+	HardDeleteTimeout                = time.Minute * 20
+	HardDeleteCheckInterval          = time.Second * 10
+	DeleteRequestTimeout             = time.Minute * 5
+	ApplyTimeout                     = time.Minute * 1
+	ApplyRetryCount                  = 3
+	ApplyRetryInterval               = time.Second * 1
+	ErrorStateRequeueInterval        = time.Duration(0)
+	ErrorStateRequeueIntervals       = ReasonDurations{}
+	ForceDeleteConfirmationThreshold = 0
+	StuckDeletionThreshold           = time.Hour * 1
+	ResourceCountMetricsInterval     = time.Minute * 5
+	StatusUpdateTimeout              = time.Second * 10
+	StatusUpdateCheckInterval        = time.Millisecond * 500
+	ChartPath                        = "./module-chart/chart"
+	ResourcesPath                    = "./module-resources"
+	ManagerResourcesPath             = "./manager-resources"
+	EnableLimitedCache               = "false"
+	EnableWebhookReadinessCheck      = "true"
+	EnableUpgradeDryRun              = "false"
+	LogLevel                         = uberzap.NewAtomicLevelAt(zapcore.InfoLevel)
+	LogFormat                        = logformat.NewFormat(logformat.JSON)
 )
 
 const (
@@ -98,17 +123,27 @@ const (
 	secretKind                                = "Secret"
 	configMapKind                             = "ConfigMap"
 	deploymentKind                            = "Deployment"
+	customResourceDefinitionKind              = "CustomResourceDefinition"
+	podDisruptionBudgetKind                   = "PodDisruptionBudget"
 	deploymentAvailableConditionType          = "Available"
 	deploymentProgressingConditionType        = "Progressing"
+	defaultWebhookServicePort                 = 443
 	operatorName                              = "btp-manager"
 	operandName                               = "sap-btp-operator"
 	moduleName                                = "btp-operator"
 	sapBtpServiceOperatorConfigMapName        = operandName + "-config"
 	sapBtpServiceOperatorClusterIdSecretName  = operandName + "-clusterid"
+	deletionInventoryConfigMapName            = operatorName + "-deletion-inventory"
+	stuckResourcesConfigMapName               = operatorName + "-stuck-resources"
+	maxMissingSecretReferencesInMessage       = 10
+	resourceCountMetricsDisabledCheckInterval = time.Minute
+	removeFinalizersAnnotationKey             = operatorLabelPrefix + "remove-finalizers"
+	retryDeletionAnnotationKey                = operatorLabelPrefix + "retry-deletion"
 	mutatingWebhookName                       = operandName + "-mutating-webhook-configuration"
 	validatingWebhookName                     = operandName + "-validating-webhook-configuration"
 	sapBtpServiceOperatorSecretName           = SapBtpServiceOperatorName
 	sapBtpServiceOperatorContainerName        = "manager"
+	enableLeaderElectionArg                   = "--enable-leader-election"
 	kubeRbacProxyContainerName                = KubeRbacProxyName
 	operatorLabelPrefix                       = "operator.kyma-project.io/"
 	deletionFinalizer                         = operatorLabelPrefix + operatorName
@@ -143,6 +178,10 @@ var (
 		Kind:    btpOperatorServiceInstance,
 	}
 	managedByLabelFilter = client.MatchingLabels{managedByLabelKey: operatorName}
+	// configLogger names the entries of the ConfigMap handler, which runs outside of the reconciliation and has no logger in its context
+	configLogger = log.Log.WithName("config")
+	// resourceCountMetricsInterval is the copy of ResourceCountMetricsInterval read by the metrics runnable concurrently with the ConfigMap updates
+	resourceCountMetricsInterval atomic.Int64
 )
 
 var (
@@ -180,6 +219,8 @@ type BtpOperatorReconciler struct {
 	clusterIdFromSapBtpServiceOperatorClusterIdSecret   string
 	credentialsNamespaceFromSapBtpManagerSecret         string
 	credentialsNamespaceFromSapBtpServiceOperatorSecret string
+	overriddenConfig                                    map[string]string
+	errorStateEnteredAt                                 time.Time
 }
 
 type ResourceReadiness struct {
@@ -221,6 +262,7 @@ func NewBtpOperatorReconciler(client client.Client, apiServerClient client.Clien
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources="rolebindings",verbs="*"
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources="roles",verbs="*"
 //+kubebuilder:rbac:groups="networking.k8s.io",resources="networkpolicies",verbs="*"
+//+kubebuilder:rbac:groups="policy",resources="poddisruptionbudgets",verbs="*"
 
 func (r *BtpOperatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.workqueueSize += 1
@@ -247,7 +289,7 @@ func (r *BtpOperatorReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, r.Update(ctx, reconcileCr)
 	}
 
-	if !reconcileCr.ObjectMeta.DeletionTimestamp.IsZero() && reconcileCr.Status.State != v1alpha1.StateDeleting && !reconcileCr.IsReasonStringEqual(string(conditions.ServiceInstancesAndBindingsNotCleaned)) {
+	if !reconcileCr.ObjectMeta.DeletionTimestamp.IsZero() && reconcileCr.Status.State != v1alpha1.StateDeleting && !r.isDeletionBlocked(reconcileCr) {
 		return ctrl.Result{}, r.UpdateBtpOperatorStatus(ctx, reconcileCr, v1alpha1.StateDeleting, conditions.HardDeleting, "BtpOperator is to be deleted")
 	}
 
@@ -259,7 +301,7 @@ func (r *BtpOperatorReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	case v1alpha1.StateWarning:
 		return r.HandleWarningState(ctx, reconcileCr)
 	case v1alpha1.StateError:
-		return ctrl.Result{}, r.HandleErrorState(ctx, reconcileCr)
+		return r.HandleErrorState(ctx, reconcileCr)
 	case v1alpha1.StateDeleting:
 		err := r.HandleDeletingState(ctx, reconcileCr)
 		if reconcileCr.IsReasonStringEqual(string(conditions.ServiceInstancesAndBindingsNotCleaned)) {
@@ -291,9 +333,12 @@ func (r *BtpOperatorReconciler) UpdateBtpOperatorStatus(ctx context.Context, cr
 			time.Sleep(StatusUpdateCheckInterval)
 			continue
 		}
-		if cr.Status.State == newState && cr.IsMsgForGivenReasonEqual(string(reason), message) {
+		// Secret references are checked only in the Ready state, so their condition is removed in other states instead of going stale
+		secretReferencesCleared := newState != v1alpha1.StateReady && conditions.RemoveStatusCondition(&cr.Status.Conditions, conditions.SecretReferencesResolvedType)
+		if !secretReferencesCleared && cr.Status.State == newState && cr.IsMsgForGivenReasonEqual(string(reason), message) {
 			return nil
 		}
+		previousState := cr.Status.State
 		cr.Status.WithState(newState)
 		newCondition := conditions.ConditionFromExistingReason(reason, message)
 		if newCondition != nil {
@@ -304,6 +349,9 @@ func (r *BtpOperatorReconciler) UpdateBtpOperatorStatus(ctx context.Context, cr
 			time.Sleep(StatusUpdateCheckInterval)
 			continue
 		}
+		if newState == v1alpha1.StateError && previousState != v1alpha1.StateError {
+			r.errorStateEnteredAt = time.Now()
+		}
 		time.Sleep(StatusUpdateCheckInterval)
 	}
 	logger.Error(err, fmt.Sprintf("timed out while waiting %s for the BtpOperator status change.", StatusUpdateTimeout.String()))
@@ -345,6 +393,9 @@ func (r *BtpOperatorReconciler) HandleProcessingState(ctx context.Context, cr *v
 	}
 
 	if err := r.reconcileResources(ctx, cr, requiredSecret); err != nil {
+		if errWithReason := errorWithReasonFrom(err); errWithReason != nil {
+			return r.UpdateBtpOperatorStatus(ctx, cr, conditions.Reasons[errWithReason.reason].State, errWithReason.reason, errWithReason.message)
+		}
 		return r.UpdateBtpOperatorStatus(ctx, cr, v1alpha1.StateError, conditions.ProvisioningFailed, err.Error())
 	}
 
@@ -355,6 +406,11 @@ func (r *BtpOperatorReconciler) HandleProcessingState(ctx context.Context, cr *v
 
 	r.instanceBindingService.EnableSISBController()
 
+	if err := r.updateReadyReplicas(ctx, cr); err != nil {
+		logger.Error(err, "while updating ready replicas")
+		return err
+	}
+
 	logger.Info("provisioning succeeded")
 	return r.UpdateBtpOperatorStatus(ctx, cr, v1alpha1.StateReady, conditions.ReconcileSucceeded, "Module provisioning succeeded")
 }
@@ -528,6 +584,12 @@ func (r *BtpOperatorReconciler) reconcileResources(ctx context.Context, cr *v1al
 		}
 	}
 
+	logger.Info("preparing workload customizations from BtpOperator spec")
+	if err = r.prepareWorkloadCustomizations(ctx, cr, &resourcesToApply); err != nil {
+		logger.Error(err, "while preparing workload customizations")
+		return fmt.Errorf("failed to prepare workload customizations: %w", err)
+	}
+
 	logger.Info("preparing module resources to apply")
 	if err = r.prepareModuleResourcesFromManifests(ctx, resourcesToApply, s); err != nil {
 		logger.Error(err, "while preparing objects to apply")
@@ -538,8 +600,25 @@ func (r *BtpOperatorReconciler) reconcileResources(ctx context.Context, cr *v1al
 		return fmt.Errorf("failed to reconcile webhook certs: %w", err)
 	}
 
+	if err = r.addCommonMetadata(cr, resourcesToApply...); err != nil {
+		logger.Error(err, "while adding common labels and annotations")
+		return fmt.Errorf("failed to add common labels and annotations: %w", err)
+	}
+
 	r.deleteCreationTimestamp(resourcesToApply...)
 
+	if err = r.checkUpgrade(ctx, cr, resourcesToApply); err != nil {
+		logger.Error(err, "while checking module resources upgrade")
+		return err
+	}
+
+	if strings.ToLower(EnableUpgradeDryRun) == "true" {
+		if err = r.dryRunUpgrade(ctx, resourcesToApply); err != nil {
+			logger.Error(err, "while dry-running module resources upgrade")
+			return err
+		}
+	}
+
 	logger.Info(fmt.Sprintf("applying module resources for %d resources", len(resourcesToApply)))
 	if err = r.applyOrUpdateResources(ctx, resourcesToApply); err != nil {
 		logger.Error(err, "while applying module resources")
@@ -552,6 +631,372 @@ func (r *BtpOperatorReconciler) reconcileResources(ctx context.Context, cr *v1al
 		return fmt.Errorf("timed out while waiting for resources readiness: %w", err)
 	}
 
+	if strings.ToLower(EnableWebhookReadinessCheck) == "true" {
+		logger.Info("checking webhook server readiness")
+		if err := r.waitForWebhookServerReadiness(ctx, resourcesToApply); err != nil {
+			logger.Error(err, "webhook server is not ready")
+			return fmt.Errorf("webhook server is not ready: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkUpgrade runs the compatibility check when the version of the module resources to apply differs from the installed one,
+// publishes the findings in the BtpOperator status, and blocks the upgrade until it is approved if the upgrade policy is Manual
+func (r *BtpOperatorReconciler) checkUpgrade(ctx context.Context, cr *v1alpha1.BtpOperator, resourcesToApply []*unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
+
+	installedVersion, targetVersion, err := r.getUpgradeVersions(ctx, resourcesToApply)
+	if err != nil {
+		return err
+	}
+	if installedVersion == "" || installedVersion == targetVersion {
+		if cr.Status.UpgradeCheck == nil {
+			return nil
+		}
+		if err := r.updateUpgradeCheck(ctx, cr, nil); err != nil {
+			return fmt.Errorf("failed to clear the upgrade check status: %w", err)
+		}
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("checking upgrade compatibility from version %s to %s", installedVersion, targetVersion))
+	findings, err := r.upgradeFindings(ctx, resourcesToApply)
+	if err != nil {
+		return fmt.Errorf("failed to check upgrade compatibility: %w", err)
+	}
+	for _, finding := range findings {
+		logger.Info(fmt.Sprintf("upgrade check finding: %s", finding))
+	}
+
+	upgradeCheck := &v1alpha1.UpgradeCheck{FromVersion: installedVersion, ToVersion: targetVersion, Findings: findings}
+	if err := r.updateUpgradeCheck(ctx, cr, upgradeCheck); err != nil {
+		return fmt.Errorf("failed to update the upgrade check status: %w", err)
+	}
+
+	if !cr.IsUpgradeApproved(targetVersion) {
+		return NewErrorWithReason(conditions.UpgradeApprovalRequired,
+			fmt.Sprintf("upgrade from version %s to %s with %d findings waits for approval with the %s annotation", installedVersion, targetVersion, len(findings), v1alpha1.UpgradeApprovedVersionAnnotation))
+	}
+
+	return nil
+}
+
+// getUpgradeVersions returns the installed version of the module resources and the version of the module resources to apply.
+// The installed version is empty if the module resources are not installed yet.
+func (r *BtpOperatorReconciler) getUpgradeVersions(ctx context.Context, resourcesToApply []*unstructured.Unstructured) (string, string, error) {
+	deployment := r.findSapBtpServiceOperatorDeployment(resourcesToApply)
+	if deployment == nil {
+		return "", "", nil
+	}
+	installedVersion, err := r.getInstalledChartVersion(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get the installed module resources version: %w", err)
+	}
+	return installedVersion, deployment.GetLabels()[chartVersionKey], nil
+}
+
+func (r *BtpOperatorReconciler) getInstalledChartVersion(ctx context.Context) (string, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKey{Name: DeploymentName, Namespace: ChartNamespace}, deployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return deployment.GetLabels()[chartVersionKey], nil
+}
+
+func (r *BtpOperatorReconciler) updateUpgradeCheck(ctx context.Context, cr *v1alpha1.BtpOperator, upgradeCheck *v1alpha1.UpgradeCheck) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cr), cr); err != nil {
+		return fmt.Errorf("while getting the BtpOperator: %w", err)
+	}
+	if reflect.DeepEqual(cr.Status.UpgradeCheck, upgradeCheck) {
+		return nil
+	}
+	cr.Status.UpgradeCheck = upgradeCheck
+
+	return r.Status().Update(ctx, cr)
+}
+
+func (r *BtpOperatorReconciler) upgradeFindings(ctx context.Context, resourcesToApply []*unstructured.Unstructured) ([]string, error) {
+	var findings []string
+	for _, u := range resourcesToApply {
+		var resourceFindings []string
+		var err error
+		switch u.GetKind() {
+		case customResourceDefinitionKind:
+			resourceFindings, err = r.crdUpgradeFindings(ctx, u)
+		case MutatingWebhookConfiguration, ValidatingWebhookConfiguration:
+			resourceFindings, err = r.webhookUpgradeFindings(ctx, u)
+		}
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, resourceFindings...)
+	}
+	return findings, nil
+}
+
+func (r *BtpOperatorReconciler) crdUpgradeFindings(ctx context.Context, u *unstructured.Unstructured) ([]string, error) {
+	installed := &apiextensionsv1.CustomResourceDefinition{}
+	if err := r.Get(ctx, client.ObjectKey{Name: u.GetName()}, installed); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while getting %s %s: %w", u.GetKind(), u.GetName(), err)
+	}
+	target := &apiextensionsv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, target); err != nil {
+		return nil, fmt.Errorf("while converting %s %s: %w", u.GetKind(), u.GetName(), err)
+	}
+
+	var findings []string
+	for _, installedVersion := range installed.Spec.Versions {
+		if !installedVersion.Served {
+			continue
+		}
+		targetVersion := findCrdVersion(target, installedVersion.Name)
+		if targetVersion == nil || !targetVersion.Served {
+			findings = append(findings, fmt.Sprintf("CRD %s: version %s is no longer served", u.GetName(), installedVersion.Name))
+			continue
+		}
+		if installedVersion.Schema == nil || targetVersion.Schema == nil {
+			continue
+		}
+		removedFields := removedSchemaFields(installedVersion.Schema.OpenAPIV3Schema, targetVersion.Schema.OpenAPIV3Schema, nil)
+		if len(removedFields) == 0 {
+			continue
+		}
+		gvk := schema.GroupVersionKind{Group: installed.Spec.Group, Version: installedVersion.Name, Kind: installed.Spec.Names.Kind}
+		resources, err := r.listResources(ctx, gvk)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range removedFields {
+			inUse := 0
+			for _, resource := range resources {
+				if isSchemaFieldSet(resource.Object, field) {
+					inUse++
+				}
+			}
+			findings = append(findings, fmt.Sprintf("CRD %s: field %s removed from version %s, used by %d existing resources", u.GetName(), schemaFieldPath(field), installedVersion.Name, inUse))
+		}
+	}
+	return findings, nil
+}
+
+func findCrdVersion(crd *apiextensionsv1.CustomResourceDefinition, name string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == name {
+			return &crd.Spec.Versions[i]
+		}
+	}
+	return nil
+}
+
+const (
+	// schemaArrayItems marks the items of an array in a schema field path
+	schemaArrayItems = "[]"
+	// schemaMapValues marks the values of a map, defined with additionalProperties, in a schema field path
+	schemaMapValues = "*"
+)
+
+// removedSchemaFields returns the paths of properties present in the installed schema and missing in the target one,
+// including the properties of array items and map values
+func removedSchemaFields(installed, target *apiextensionsv1.JSONSchemaProps, path []string) [][]string {
+	if installed == nil || target == nil {
+		return nil
+	}
+	childPath := func(name string) []string {
+		return append(append([]string{}, path...), name)
+	}
+	var removed [][]string
+	for name, installedProperty := range installed.Properties {
+		targetProperty, found := target.Properties[name]
+		if !found {
+			removed = append(removed, childPath(name))
+			continue
+		}
+		removed = append(removed, removedSchemaFields(&installedProperty, &targetProperty, childPath(name))...)
+	}
+	if installed.Items != nil && target.Items != nil {
+		removed = append(removed, removedSchemaFields(installed.Items.Schema, target.Items.Schema, childPath(schemaArrayItems))...)
+	}
+	if installed.AdditionalProperties != nil && target.AdditionalProperties != nil {
+		removed = append(removed, removedSchemaFields(installed.AdditionalProperties.Schema, target.AdditionalProperties.Schema, childPath(schemaMapValues))...)
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return schemaFieldPath(removed[i]) < schemaFieldPath(removed[j])
+	})
+	return removed
+}
+
+func schemaFieldPath(field []string) string {
+	return strings.ReplaceAll(strings.Join(field, "."), "."+schemaArrayItems, schemaArrayItems)
+}
+
+// isSchemaFieldSet reports whether the field is set in the object, in any array item or map value on its path
+func isSchemaFieldSet(value interface{}, field []string) bool {
+	if len(field) == 0 {
+		return true
+	}
+	switch field[0] {
+	case schemaArrayItems:
+		items, _ := value.([]interface{})
+		for _, item := range items {
+			if isSchemaFieldSet(item, field[1:]) {
+				return true
+			}
+		}
+	case schemaMapValues:
+		values, _ := value.(map[string]interface{})
+		for _, v := range values {
+			if isSchemaFieldSet(v, field[1:]) {
+				return true
+			}
+		}
+	default:
+		object, _ := value.(map[string]interface{})
+		if child, found := object[field[0]]; found {
+			return isSchemaFieldSet(child, field[1:])
+		}
+	}
+	return false
+}
+
+func (r *BtpOperatorReconciler) webhookUpgradeFindings(ctx context.Context, u *unstructured.Unstructured) ([]string, error) {
+	installed := &unstructured.Unstructured{}
+	installed.SetGroupVersionKind(u.GroupVersionKind())
+	if err := r.Get(ctx, client.ObjectKey{Name: u.GetName()}, installed); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while getting %s %s: %w", u.GetKind(), u.GetName(), err)
+	}
+
+	installedWebhooks, err := webhooksByName(installed)
+	if err != nil {
+		return nil, err
+	}
+	targetWebhooks, err := webhooksByName(u)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []string
+	for name, installedWebhook := range installedWebhooks {
+		targetWebhook, found := targetWebhooks[name]
+		if !found {
+			findings = append(findings, fmt.Sprintf("%s %s: webhook %s removed", u.GetKind(), u.GetName(), name))
+			continue
+		}
+		if installedWebhook["failurePolicy"] != targetWebhook["failurePolicy"] {
+			findings = append(findings, fmt.Sprintf("%s %s: webhook %s failure policy changed from %v to %v", u.GetKind(), u.GetName(), name, installedWebhook["failurePolicy"], targetWebhook["failurePolicy"]))
+		}
+		if !reflect.DeepEqual(webhookRules(installedWebhook), webhookRules(targetWebhook)) {
+			findings = append(findings, fmt.Sprintf("%s %s: webhook %s rules changed", u.GetKind(), u.GetName(), name))
+		}
+	}
+	for name := range targetWebhooks {
+		if _, found := installedWebhooks[name]; !found {
+			findings = append(findings, fmt.Sprintf("%s %s: webhook %s added", u.GetKind(), u.GetName(), name))
+		}
+	}
+	sort.Strings(findings)
+	return findings, nil
+}
+
+func webhooksByName(u *unstructured.Unstructured) (map[string]map[string]interface{}, error) {
+	// the webhooks are read without a deep copy, because the CA bundle set by prepareWebhookReconciliationData is a []byte that cannot be deep copied
+	webhooksValue, _, err := unstructured.NestedFieldNoCopy(u.Object, "webhooks")
+	if err != nil {
+		return nil, fmt.Errorf("while getting webhooks from %s %s: %w", u.GetKind(), u.GetName(), err)
+	}
+	webhooks, _ := webhooksValue.([]interface{})
+	result := make(map[string]map[string]interface{}, len(webhooks))
+	for _, w := range webhooks {
+		webhook, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := webhook["name"].(string)
+		result[name] = webhook
+	}
+	return result, nil
+}
+
+// webhookRules returns the operations, API groups, API versions and resources of the webhook rules.
+// Other fields, such as scope, are left out, because the API server sets their defaults on the installed webhooks.
+func webhookRules(webhook map[string]interface{}) []map[string]interface{} {
+	rulesValue, _ := webhook["rules"].([]interface{})
+	rules := make([]map[string]interface{}, 0, len(rulesValue))
+	for _, r := range rulesValue {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rules = append(rules, map[string]interface{}{
+			"operations":  rule["operations"],
+			"apiGroups":   rule["apiGroups"],
+			"apiVersions": rule["apiVersions"],
+			"resources":   rule["resources"],
+		})
+	}
+	return rules
+}
+
+// dryRunUpgrade applies a new version of the module resources with the server-side dry run before the live resources are upgraded.
+// The resources are applied in their own namespaces, so the API server and the admission webhooks check them against the live resources without persisting anything.
+func (r *BtpOperatorReconciler) dryRunUpgrade(ctx context.Context, resourcesToApply []*unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
+
+	installedVersion, targetVersion, err := r.getUpgradeVersions(ctx, resourcesToApply)
+	if err != nil {
+		return err
+	}
+	if installedVersion == "" || installedVersion == targetVersion {
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("dry-running upgrade from version %s to %s", installedVersion, targetVersion))
+
+	for _, resource := range resourcesToApply {
+		u, err := copyUnstructured(resource)
+		if err != nil {
+			return err
+		}
+		if err := r.dryRunApply(ctx, u); err != nil {
+			return NewErrorWithReason(conditions.UpgradeDryRunFailed, fmt.Sprintf("dry run of the upgrade to version %s failed: %s", targetVersion, err))
+		}
+	}
+
+	logger.Info(fmt.Sprintf("upgrade dry run to version %s succeeded", targetVersion))
+	return nil
+}
+
+// copyUnstructured copies the object through JSON, because DeepCopy panics on the []byte CA bundle set by prepareWebhookReconciliationData
+func copyUnstructured(u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	data, err := u.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("while copying %s %s: %w", u.GetKind(), u.GetName(), err)
+	}
+	c := &unstructured.Unstructured{}
+	if err := c.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("while copying %s %s: %w", u.GetKind(), u.GetName(), err)
+	}
+	return c, nil
+}
+
+func (r *BtpOperatorReconciler) dryRunApply(ctx context.Context, u *unstructured.Unstructured) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, ApplyTimeout)
+	defer cancel()
+
+	u.SetResourceVersion("")
+	if err := r.Patch(ctxWithTimeout, u, client.Apply, client.ForceOwnership, client.FieldOwner(operatorName), client.DryRunAll); err != nil {
+		return fmt.Errorf("while applying %s %s: %w", u.GetKind(), u.GetName(), err)
+	}
 	return nil
 }
 
@@ -638,6 +1083,109 @@ func (r *BtpOperatorReconciler) cleanupNetworkPolicies(ctx context.Context) erro
 	return nil
 }
 
+func (r *BtpOperatorReconciler) prepareWorkloadCustomizations(ctx context.Context, cr *v1alpha1.BtpOperator, resourcesToApply *[]*unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
+
+	deployment := r.findSapBtpServiceOperatorDeployment(*resourcesToApply)
+	if deployment == nil {
+		return fmt.Errorf("%s %s not found in module resources", deploymentKind, DeploymentName)
+	}
+
+	if cr.Spec.Replicas != nil {
+		logger.Info(fmt.Sprintf("setting %d replicas in %s %s", *cr.Spec.Replicas, deployment.GetName(), deployment.GetKind()))
+		if err := unstructured.SetNestedField(deployment.Object, int64(*cr.Spec.Replicas), "spec", "replicas"); err != nil {
+			return fmt.Errorf("failed to set replicas in %s %s: %w", deployment.GetName(), deployment.GetKind(), err)
+		}
+		if *cr.Spec.Replicas > 1 {
+			logger.Info("more than one replica requested, enabling leader election")
+			if err := r.addContainerArg(deployment, sapBtpServiceOperatorContainerName, enableLeaderElectionArg); err != nil {
+				return fmt.Errorf("failed to enable leader election: %w", err)
+			}
+		}
+	}
+
+	if cr.Spec.PriorityClassName != "" {
+		logger.Info(fmt.Sprintf("setting %s priority class in %s %s", cr.Spec.PriorityClassName, deployment.GetName(), deployment.GetKind()))
+		if err := unstructured.SetNestedField(deployment.Object, cr.Spec.PriorityClassName, "spec", "template", "spec", "priorityClassName"); err != nil {
+			return fmt.Errorf("failed to set priority class in %s %s: %w", deployment.GetName(), deployment.GetKind(), err)
+		}
+	}
+
+	if cr.Spec.PodDisruptionBudget == nil {
+		logger.Info("pod disruption budget not configured, cleaning up existing one")
+		if err := r.cleanupPodDisruptionBudgets(ctx); err != nil {
+			return fmt.Errorf("failed to cleanup pod disruption budgets: %w", err)
+		}
+		return nil
+	}
+
+	pdb, err := r.buildPodDisruptionBudget(cr.Spec.PodDisruptionBudget, deployment)
+	if err != nil {
+		return err
+	}
+	*resourcesToApply = append(*resourcesToApply, pdb)
+	logger.Info(fmt.Sprintf("added %s %s to resources to apply", pdb.GetKind(), pdb.GetName()))
+
+	return nil
+}
+
+func (r *BtpOperatorReconciler) findSapBtpServiceOperatorDeployment(us []*unstructured.Unstructured) *unstructured.Unstructured {
+	for _, u := range us {
+		if u.GetName() == DeploymentName && u.GetKind() == deploymentKind {
+			return u
+		}
+	}
+	return nil
+}
+
+func (r *BtpOperatorReconciler) buildPodDisruptionBudget(pdbSpec *v1alpha1.PodDisruptionBudgetSpec, deployment *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	selectorLabels, found, err := unstructured.NestedStringMap(deployment.Object, "spec", "selector", "matchLabels")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get selector from %s %s: %w", deployment.GetName(), deployment.GetKind(), err)
+	}
+	if !found || len(selectorLabels) == 0 {
+		return nil, fmt.Errorf("selector not found in %s %s", deployment.GetName(), deployment.GetKind())
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       podDisruptionBudgetKind,
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeploymentName,
+			Namespace: ChartNamespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: selectorLabels},
+			MinAvailable:   pdbSpec.MinAvailable,
+			MaxUnavailable: pdbSpec.MaxUnavailable,
+		},
+	}
+	if pdb.Spec.MinAvailable == nil && pdb.Spec.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt32(1)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+
+	unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pdb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s to unstructured: %w", podDisruptionBudgetKind, err)
+	}
+	unstructured.RemoveNestedField(unstructuredObj, "status")
+
+	return &unstructured.Unstructured{Object: unstructuredObj}, nil
+}
+
+func (r *BtpOperatorReconciler) cleanupPodDisruptionBudgets(ctx context.Context) error {
+	if err := r.DeleteAllOf(ctx, &policyv1.PodDisruptionBudget{}, client.InNamespace(ChartNamespace), managedByLabelFilter); err != nil {
+		if !(k8serrors.IsNotFound(err) || k8serrors.IsMethodNotSupported(err) || meta.IsNoMatchError(err)) {
+			return fmt.Errorf("failed to delete pod disruption budgets: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *BtpOperatorReconciler) addLabels(chartVer string, us ...*unstructured.Unstructured) error {
 
 	for _, u := range us {
@@ -667,6 +1215,49 @@ func (r *BtpOperatorReconciler) addLabels(chartVer string, us ...*unstructured.U
 	return nil
 }
 
+// addCommonMetadata adds the common labels and annotations from the BtpOperator spec to the module resources and to the Deployment Pod templates.
+// Labels and annotations that are already set are not overridden.
+func (r *BtpOperatorReconciler) addCommonMetadata(cr *v1alpha1.BtpOperator, us ...*unstructured.Unstructured) error {
+	if len(cr.Spec.CommonLabels) == 0 && len(cr.Spec.CommonAnnotations) == 0 {
+		return nil
+	}
+	for _, u := range us {
+		u.SetLabels(mergeWithoutOverride(u.GetLabels(), cr.Spec.CommonLabels))
+		u.SetAnnotations(mergeWithoutOverride(u.GetAnnotations(), cr.Spec.CommonAnnotations))
+		if u.GetKind() != deploymentKind {
+			continue
+		}
+		for field, common := range map[string]map[string]string{"labels": cr.Spec.CommonLabels, "annotations": cr.Spec.CommonAnnotations} {
+			current, _, err := unstructured.NestedStringMap(u.Object, "spec", "template", "metadata", field)
+			if err != nil {
+				return fmt.Errorf("failed to get pod template %s for deployment %s: %w", field, u.GetName(), err)
+			}
+			merged := mergeWithoutOverride(current, common)
+			if len(merged) == 0 {
+				continue
+			}
+			if err := unstructured.SetNestedStringMap(u.Object, merged, "spec", "template", "metadata", field); err != nil {
+				return fmt.Errorf("failed to set pod template %s for deployment %s: %w", field, u.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func mergeWithoutOverride(current, common map[string]string) map[string]string {
+	if len(common) == 0 {
+		return current
+	}
+	merged := make(map[string]string, len(current)+len(common))
+	for k, v := range common {
+		merged[k] = v
+	}
+	for k, v := range current {
+		merged[k] = v
+	}
+	return merged
+}
+
 func (r *BtpOperatorReconciler) setNamespace(us ...*unstructured.Unstructured) {
 	for _, u := range us {
 		u.SetNamespace(ChartNamespace)
@@ -748,39 +1339,109 @@ func (r *BtpOperatorReconciler) setContainerImage(u *unstructured.Unstructured,
 	return unstructured.SetNestedSlice(u.Object, containers, "spec", "template", "spec", "containers")
 }
 
+func (r *BtpOperatorReconciler) addContainerArg(u *unstructured.Unstructured, containerName, arg string) error {
+	containers, found, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+	if err != nil {
+		return fmt.Errorf("failed to get containers from %s %s: %w", u.GetKind(), u.GetName(), err)
+	}
+	if !found {
+		return fmt.Errorf("containers not found in %s %s", u.GetKind(), u.GetName())
+	}
+	for i, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot cast container field to map[string]interface{}: %v", c)
+		}
+		if container["name"] != containerName {
+			continue
+		}
+		args, _, err := unstructured.NestedStringSlice(container, "args")
+		if err != nil {
+			return fmt.Errorf("failed to get args of %s container: %w", containerName, err)
+		}
+		for _, a := range args {
+			if a == arg {
+				return nil
+			}
+		}
+		if err := unstructured.SetNestedStringSlice(container, append(args, arg), "args"); err != nil {
+			return fmt.Errorf("failed to set args of %s container: %w", containerName, err)
+		}
+		containers[i] = container
+		return unstructured.SetNestedSlice(u.Object, containers, "spec", "template", "spec", "containers")
+	}
+
+	return fmt.Errorf("container %s not found in %s %s", containerName, u.GetKind(), u.GetName())
+}
+
 func (r *BtpOperatorReconciler) applyOrUpdateResources(ctx context.Context, us []*unstructured.Unstructured) error {
 	logger := log.FromContext(ctx)
 	for _, u := range us {
-		preExistingResource := &unstructured.Unstructured{}
-		preExistingResource.SetGroupVersionKind(u.GroupVersionKind())
-		if err := r.Get(ctx, client.ObjectKey{Name: u.GetName(), Namespace: u.GetNamespace()}, preExistingResource); err != nil {
-			if !k8serrors.IsNotFound(err) {
-				return fmt.Errorf("while trying to get %s %s: %w", u.GetName(), u.GetKind(), err)
-			}
-			logger.Info(fmt.Sprintf("applying %s - %s", u.GetKind(), u.GetName()))
-			if err := r.Patch(ctx, u, client.Apply, client.ForceOwnership, client.FieldOwner(operatorName)); err != nil {
-				return fmt.Errorf("while applying %s %s: %w", u.GetName(), u.GetKind(), err)
+		var err error
+		for attempt := 0; attempt <= ApplyRetryCount; attempt++ {
+			if attempt > 0 {
+				logger.Info(fmt.Sprintf("retrying %s %s apply (%d/%d) after error: %s", u.GetKind(), u.GetName(), attempt, ApplyRetryCount, err))
+				time.Sleep(ApplyRetryInterval)
 			}
-		} else {
-			logger.Info(fmt.Sprintf("updating %s - %s", u.GetKind(), u.GetName()))
-			u.SetResourceVersion(preExistingResource.GetResourceVersion())
-			if err := r.Update(ctx, u, client.FieldOwner(operatorName)); err != nil {
-				return fmt.Errorf("while updating %s %s: %w", u.GetName(), u.GetKind(), err)
+			if err = r.applyOrUpdateResource(ctx, u); err == nil || !isRetryableApplyError(err) {
+				break
 			}
 		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *BtpOperatorReconciler) applyOrUpdateResource(ctx context.Context, u *unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, ApplyTimeout)
+	defer cancel()
+
+	preExistingResource := &unstructured.Unstructured{}
+	preExistingResource.SetGroupVersionKind(u.GroupVersionKind())
+	if err := r.Get(ctxWithTimeout, client.ObjectKey{Name: u.GetName(), Namespace: u.GetNamespace()}, preExistingResource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("while trying to get %s %s: %w", u.GetName(), u.GetKind(), err)
+		}
+		logger.Info(fmt.Sprintf("applying %s - %s", u.GetKind(), u.GetName()))
+		if err := r.Patch(ctxWithTimeout, u, client.Apply, client.ForceOwnership, client.FieldOwner(operatorName)); err != nil {
+			return fmt.Errorf("while applying %s %s: %w", u.GetName(), u.GetKind(), err)
+		}
+	} else {
+		logger.Info(fmt.Sprintf("updating %s - %s", u.GetKind(), u.GetName()))
+		u.SetResourceVersion(preExistingResource.GetResourceVersion())
+		if err := r.Update(ctxWithTimeout, u, client.FieldOwner(operatorName)); err != nil {
+			return fmt.Errorf("while updating %s %s: %w", u.GetName(), u.GetKind(), err)
+		}
 	}
 	return nil
 }
 
+// isRetryableApplyError reports errors that can disappear on their own, for example on busy or slow API servers
+func isRetryableApplyError(err error) bool {
+	return k8serrors.IsConflict(err) ||
+		k8serrors.IsServerTimeout(err) ||
+		k8serrors.IsTimeout(err) ||
+		k8serrors.IsTooManyRequests(err) ||
+		k8serrors.IsServiceUnavailable(err) ||
+		k8serrors.IsInternalError(err) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
 func (r *BtpOperatorReconciler) waitForResourcesReadiness(ctx context.Context, us []*unstructured.Unstructured) error {
 	numOfResources := len(us)
 	resourcesReadinessInformer := make(chan ResourceReadiness, numOfResources)
 	for _, u := range us {
-		if u.GetKind() == deploymentKind {
+		switch u.GetKind() {
+		case deploymentKind:
 			go r.checkDeploymentReadiness(ctx, u, resourcesReadinessInformer)
-			continue
+		case customResourceDefinitionKind:
+			go r.checkCrdEstablished(ctx, u, resourcesReadinessInformer)
+		default:
+			go r.checkResourceExistence(ctx, u, resourcesReadinessInformer)
 		}
-		go r.checkResourceExistence(ctx, u, resourcesReadinessInformer)
 	}
 
 	for i := 0; i < numOfResources; i++ {
@@ -827,6 +1488,152 @@ func (r *BtpOperatorReconciler) checkDeploymentReadiness(ctx context.Context, u
 	}
 }
 
+func (r *BtpOperatorReconciler) checkCrdEstablished(ctx context.Context, u *unstructured.Unstructured, c chan<- ResourceReadiness) {
+	logger := log.FromContext(ctx)
+
+	got := &apiextensionsv1.CustomResourceDefinition{}
+	err := wait.PollUntilContextTimeout(ctx, ReadyCheckInterval, ReadyTimeout, true, func(ctx context.Context) (bool, error) {
+		if err := r.Get(ctx, client.ObjectKey{Name: u.GetName()}, got); err != nil {
+			return false, nil
+		}
+		for _, condition := range got.Status.Conditions {
+			if condition.Type == apiextensionsv1.Established && condition.Status == apiextensionsv1.ConditionTrue {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("timed out while checking %s %s established condition", u.GetName(), u.GetKind()))
+		c <- ResourceReadiness{
+			Name:      u.GetName(),
+			Namespace: u.GetNamespace(),
+			Kind:      u.GetKind(),
+			Ready:     false,
+		}
+		return
+	}
+	c <- ResourceReadiness{Ready: true}
+}
+
+type webhookServerEndpoint struct {
+	host     string
+	port     int64
+	caBundle []byte
+}
+
+func (e webhookServerEndpoint) address() string {
+	return net.JoinHostPort(e.host, strconv.FormatInt(e.port, 10))
+}
+
+func (r *BtpOperatorReconciler) waitForWebhookServerReadiness(ctx context.Context, us []*unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
+
+	endpoints, err := r.getWebhookServerEndpoints(us)
+	if err != nil {
+		return err
+	}
+
+	for _, endpoint := range endpoints {
+		var handshakeErr error
+		err := wait.PollUntilContextTimeout(ctx, ReadyCheckInterval, ReadyTimeout, true, func(ctx context.Context) (bool, error) {
+			handshakeErr = r.checkWebhookServerHandshake(ctx, endpoint)
+			return handshakeErr == nil, nil
+		})
+		if err != nil {
+			if handshakeErr == nil {
+				handshakeErr = err
+			}
+			logger.Error(handshakeErr, fmt.Sprintf("timed out while checking TLS handshake with %s", endpoint.address()))
+			return fmt.Errorf("TLS handshake with %s failed: %w", endpoint.address(), handshakeErr)
+		}
+	}
+	return nil
+}
+
+func (r *BtpOperatorReconciler) getWebhookServerEndpoints(us []*unstructured.Unstructured) ([]webhookServerEndpoint, error) {
+	endpoints := make([]webhookServerEndpoint, 0)
+	seen := make(map[string]struct{})
+	for _, u := range us {
+		if u.GetKind() != MutatingWebhookConfiguration && u.GetKind() != ValidatingWebhookConfiguration {
+			continue
+		}
+		// the webhooks are read without a deep copy, because the CA bundle set by prepareWebhookReconciliationData is a []byte that cannot be deep copied
+		webhooksValue, _, err := unstructured.NestedFieldNoCopy(u.Object, "webhooks")
+		if err != nil {
+			return nil, fmt.Errorf("while getting webhooks from %s %s: %w", u.GetKind(), u.GetName(), err)
+		}
+		webhooks, _ := webhooksValue.([]interface{})
+		for _, w := range webhooks {
+			webhook, ok := w.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, found, _ := unstructured.NestedString(webhook, "clientConfig", "service", "name")
+			if !found {
+				continue
+			}
+			namespace, _, _ := unstructured.NestedString(webhook, "clientConfig", "service", "namespace")
+			port, found, _ := unstructured.NestedInt64(webhook, "clientConfig", "service", "port")
+			if !found {
+				port = defaultWebhookServicePort
+			}
+			caBundle, err := webhookCaBundle(webhook)
+			if err != nil {
+				return nil, fmt.Errorf("while getting CA bundle of %s webhook: %w", u.GetName(), err)
+			}
+
+			endpoint := webhookServerEndpoint{
+				host:     fmt.Sprintf("%s.%s.svc", name, namespace),
+				port:     port,
+				caBundle: caBundle,
+			}
+			if _, exists := seen[endpoint.address()]; exists {
+				continue
+			}
+			seen[endpoint.address()] = struct{}{}
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+// webhookCaBundle accepts the CA bundle both as raw bytes, as set by prepareWebhookReconciliationData, and as the base64 string read from the API server
+func webhookCaBundle(webhook map[string]interface{}) ([]byte, error) {
+	value, found, err := unstructured.NestedFieldNoCopy(webhook, "clientConfig", "caBundle")
+	if err != nil || !found {
+		return nil, err
+	}
+	switch caBundle := value.(type) {
+	case []byte:
+		return caBundle, nil
+	case string:
+		return base64.StdEncoding.DecodeString(caBundle)
+	default:
+		return nil, fmt.Errorf(".clientConfig.caBundle is of the type %T, expected []byte or string", value)
+	}
+}
+
+func (r *BtpOperatorReconciler) checkWebhookServerHandshake(ctx context.Context, endpoint webhookServerEndpoint) error {
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(endpoint.caBundle) {
+		return fmt.Errorf("CA bundle for %s does not contain any valid certificate", endpoint.address())
+	}
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: ReadyCheckInterval},
+		Config: &tls.Config{
+			RootCAs:    rootCAs,
+			ServerName: endpoint.host,
+			MinVersion: tls.VersionTLS12,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint.address())
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
 func (r *BtpOperatorReconciler) checkResourceExistence(ctx context.Context, u *unstructured.Unstructured, c chan<- ResourceReadiness) {
 	logger := log.FromContext(ctx)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, ReadyCheckInterval)
@@ -858,22 +1665,73 @@ func (r *BtpOperatorReconciler) HandleWarningState(ctx context.Context, cr *v1al
 	logger := log.FromContext(ctx)
 	logger.Info("Handling Warning state")
 
-	if cr.IsReasonStringEqual(string(conditions.ServiceInstancesAndBindingsNotCleaned)) {
+	if r.isDeletionBlocked(cr) {
 		err := r.handleDeleting(ctx, cr)
-		if cr.IsReasonStringEqual(string(conditions.ServiceInstancesAndBindingsNotCleaned)) {
+		if r.isDeletionBlocked(cr) {
 			return ctrl.Result{RequeueAfter: ReadyStateRequeueInterval}, err
 		}
 		return ctrl.Result{}, err
 	}
 
+	if r.isUpgradeApprovalPending(cr) {
+		logger.Info("module resources upgrade waits for approval")
+		return ctrl.Result{RequeueAfter: ReadyStateRequeueInterval}, nil
+	}
+
 	return ctrl.Result{}, r.UpdateBtpOperatorStatus(ctx, cr, v1alpha1.StateProcessing, conditions.Updated, "CR has been updated")
 }
 
-func (r *BtpOperatorReconciler) HandleErrorState(ctx context.Context, cr *v1alpha1.BtpOperator) error {
+func (r *BtpOperatorReconciler) isUpgradeApprovalPending(cr *v1alpha1.BtpOperator) bool {
+	return cr.IsReasonStringEqual(string(conditions.UpgradeApprovalRequired)) &&
+		cr.Status.UpgradeCheck != nil && !cr.IsUpgradeApproved(cr.Status.UpgradeCheck.ToVersion)
+}
+
+func (r *BtpOperatorReconciler) isDeletionBlocked(cr *v1alpha1.BtpOperator) bool {
+	return cr.IsReasonStringEqual(string(conditions.ServiceInstancesAndBindingsNotCleaned)) ||
+		cr.IsReasonStringEqual(string(conditions.ForceDeleteConfirmationRequired))
+}
+
+func (r *BtpOperatorReconciler) HandleErrorState(ctx context.Context, cr *v1alpha1.BtpOperator) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Handling Error state")
 
-	return r.UpdateBtpOperatorStatus(ctx, cr, v1alpha1.StateProcessing, conditions.Updated, "CR has been updated")
+	if remaining := r.errorStateBackoffRemaining(cr); remaining > 0 {
+		logger.Info(fmt.Sprintf("retrying reconciliation after error in %s", remaining.String()))
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	return ctrl.Result{}, r.UpdateBtpOperatorStatus(ctx, cr, v1alpha1.StateProcessing, conditions.Updated, "CR has been updated")
+}
+
+// errorStateBackoffRemaining returns the time left until the reconciliation in the Error state is retried,
+// using the interval configured for the reason of the Ready condition or ErrorStateRequeueInterval otherwise.
+// The interval is measured from the last transition to the Error state, because the Ready condition keeps its
+// transition time while its status stays False through the Processing state between two errors.
+func (r *BtpOperatorReconciler) errorStateBackoffRemaining(cr *v1alpha1.BtpOperator) time.Duration {
+	interval := ErrorStateRequeueInterval
+	if condition := meta.FindStatusCondition(conditionsWithoutNil(cr.Status.Conditions), conditions.ReadyType); condition != nil {
+		if reasonInterval, found := ErrorStateRequeueIntervals[conditions.Reason(condition.Reason)]; found {
+			interval = reasonInterval
+		}
+	}
+	if interval <= 0 {
+		return 0
+	}
+	// the transition time is not known after a restart of the manager, so the interval starts with the first Error state handling
+	if r.errorStateEnteredAt.IsZero() {
+		r.errorStateEnteredAt = time.Now()
+	}
+	return time.Until(r.errorStateEnteredAt.Add(interval))
+}
+
+func conditionsWithoutNil(cs []*metav1.Condition) []metav1.Condition {
+	result := make([]metav1.Condition, 0, len(cs))
+	for _, c := range cs {
+		if c != nil {
+			result = append(result, *c)
+		}
+	}
+	return result
 }
 
 func (r *BtpOperatorReconciler) HandleDeletingState(ctx context.Context, cr *v1alpha1.BtpOperator) error {
@@ -904,6 +1762,11 @@ func (r *BtpOperatorReconciler) handleDeleting(ctx context.Context, cr *v1alpha1
 		r.reconcileResourcesWithoutChangingCrState(ctx, cr, &logger)
 		return err
 	}
+	if cr.IsReasonStringEqual(string(conditions.ForceDeleteConfirmationRequired)) {
+		r.reconcileResourcesWithoutChangingCrState(ctx, cr, &logger)
+		logger.Info("force delete is waiting for confirmation - leaving deletion")
+		return nil
+	}
 	if cr.IsReasonStringEqual(string(conditions.ServiceInstancesAndBindingsNotCleaned)) {
 		r.reconcileResourcesWithoutChangingCrState(ctx, cr, &logger)
 
@@ -967,7 +1830,16 @@ func (r *BtpOperatorReconciler) handleDeprovisioning(ctx context.Context, cr *v1
 			return nil
 		}
 	}
-	if cr.IsReasonStringEqual(string(conditions.ServiceInstancesAndBindingsNotCleaned)) {
+	if r.IsForceDelete(cr) && ForceDeleteConfirmationThreshold > 0 {
+		confirmationRequired, err := r.checkForceDeleteConfirmation(ctx, cr)
+		if err != nil {
+			return err
+		}
+		if confirmationRequired {
+			return nil
+		}
+	}
+	if r.isDeletionBlocked(cr) {
 		// go to a state which starts deleting process
 		if updateStatusErr := r.UpdateBtpOperatorStatus(ctx, cr,
 			v1alpha1.StateDeleting, conditions.HardDeleting,
@@ -1148,25 +2020,104 @@ func (r *BtpOperatorReconciler) resourcesExist(ctx context.Context, namespaces *
 		}
 	}
 
-	return false, nil
+	return false, nil
+}
+
+func (r *BtpOperatorReconciler) numberOfResources(ctx context.Context, gvk schema.GroupVersionKind) (int, error) {
+	items, err := r.listResources(ctx, gvk)
+	if err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+func (r *BtpOperatorReconciler) listResources(ctx context.Context, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	exists, err := r.crdExists(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+	err = r.List(ctx, list, client.InNamespace(corev1.NamespaceAll))
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// checkForceDeleteConfirmation stores the inventory of ServiceInstances and ServiceBindings to be deleted
+// and reports whether the force delete must wait for the confirmation annotation
+func (r *BtpOperatorReconciler) checkForceDeleteConfirmation(ctx context.Context, cr *v1alpha1.BtpOperator) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	instances, err := r.listResources(ctx, instanceGvk)
+	if err != nil {
+		return false, err
+	}
+	bindings, err := r.listResources(ctx, bindingGvk)
+	if err != nil {
+		return false, err
+	}
+	if err := r.storeDeletionInventory(ctx, instances, bindings); err != nil {
+		logger.Error(err, "while storing deletion inventory")
+		return false, fmt.Errorf("failed to store deletion inventory: %w", err)
+	}
+
+	if len(instances)+len(bindings) <= ForceDeleteConfirmationThreshold || cr.IsForceDeleteConfirmed() {
+		return false, nil
+	}
+
+	msg := fmt.Sprintf("Force delete of %d instance(s) and %d binding(s) requires the %s annotation set to \"true\". The affected resources are listed in the %s ConfigMap",
+		len(instances), len(bindings), v1alpha1.ForceDeleteConfirmedAnnotation, deletionInventoryConfigMapName)
+	logger.Info(msg)
+	if cr.Status.State == v1alpha1.StateWarning && cr.IsMsgForGivenReasonEqual(string(conditions.ForceDeleteConfirmationRequired), msg) {
+		return true, nil
+	}
+	return true, r.UpdateBtpOperatorStatus(ctx, cr, v1alpha1.StateWarning, conditions.ForceDeleteConfirmationRequired, msg)
+}
+
+func (r *BtpOperatorReconciler) storeDeletionInventory(ctx context.Context, instances, bindings []unstructured.Unstructured) error {
+	return r.applyReportConfigMap(ctx, deletionInventoryConfigMapName, map[string]interface{}{
+		"serviceInstances": resourcesInventory(instances),
+		"serviceBindings":  resourcesInventory(bindings),
+	})
 }
 
-func (r *BtpOperatorReconciler) numberOfResources(ctx context.Context, gvk schema.GroupVersionKind) (int, error) {
-	exists, err := r.crdExists(ctx, gvk)
-	if err != nil {
-		return 0, err
+// applyReportConfigMap stores the data in a ConfigMap labeled as managed by BTP Manager, so that it is deleted together with the module resources
+func (r *BtpOperatorReconciler) applyReportConfigMap(ctx context.Context, name string, data map[string]interface{}) error {
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind(configMapKind)
+	cm.SetName(name)
+	cm.SetNamespace(ChartNamespace)
+	cm.SetLabels(map[string]string{managedByLabelKey: operatorName})
+	if err := unstructured.SetNestedField(cm.Object, data, "data"); err != nil {
+		return err
 	}
-	if !exists {
-		return 0, nil
+	return r.Patch(ctx, cm, client.Apply, client.ForceOwnership, client.FieldOwner(operatorName))
+}
+
+func (r *BtpOperatorReconciler) cleanupReportConfigMaps(ctx context.Context) error {
+	for _, name := range []string{deletionInventoryConfigMapName, stuckResourcesConfigMapName} {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ChartNamespace}}
+		if err := r.Delete(ctx, cm); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s ConfigMap: %w", name, err)
+		}
 	}
 
-	list := &unstructured.UnstructuredList{}
-	list.SetGroupVersionKind(gvk)
-	err = r.List(ctx, list, client.InNamespace(corev1.NamespaceAll))
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+func resourcesInventory(items []unstructured.Unstructured) string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, fmt.Sprintf("%s/%s", item.GetNamespace(), item.GetName()))
 	}
-	return len(list.Items), nil
+	return strings.Join(names, "\n")
 }
 
 func (r *BtpOperatorReconciler) deleteBtpOperatorResources(ctx context.Context) error {
@@ -1201,6 +2152,16 @@ func (r *BtpOperatorReconciler) deleteBtpOperatorResources(ctx context.Context)
 		return fmt.Errorf("failed to cleanup network policies during hard delete: %w", err)
 	}
 
+	if err := r.cleanupPodDisruptionBudgets(ctx); err != nil {
+		logger.Error(err, "while cleaning up pod disruption budgets")
+		return err
+	}
+
+	if err := r.cleanupReportConfigMaps(ctx); err != nil {
+		logger.Error(err, "while cleaning up report ConfigMaps")
+		return err
+	}
+
 	clusterIdSecret, err := r.getSecretByNameAndNamespace(ctx, sapBtpServiceOperatorClusterIdSecretName, r.credentialsNamespaceFromSapBtpManagerSecret)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf("while getting %s secret in %s namespace", sapBtpServiceOperatorClusterIdSecretName, r.credentialsNamespaceFromSapBtpManagerSecret))
@@ -1432,16 +2393,356 @@ func (r *BtpOperatorReconciler) HandleReadyState(ctx context.Context, cr *v1alph
 	}
 
 	if err := r.reconcileResources(ctx, cr, requiredSecret); err != nil {
+		if errWithReason := errorWithReasonFrom(err); errWithReason != nil {
+			return r.UpdateBtpOperatorStatus(ctx, cr, conditions.Reasons[errWithReason.reason].State, errWithReason.reason, errWithReason.message)
+		}
 		return r.UpdateBtpOperatorStatus(ctx, cr, v1alpha1.StateError, conditions.ReconcileFailed, err.Error())
 	}
 
+	if err := r.updateReadyReplicas(ctx, cr); err != nil {
+		logger.Error(err, "while updating ready replicas")
+		return err
+	}
+
+	if err := r.handleStuckResources(ctx); err != nil {
+		logger.Error(err, "while handling service instances and bindings stuck in deletion")
+	}
+
+	if err := r.checkSecretReferences(ctx, cr); err != nil {
+		logger.Error(err, "while checking Secrets referenced by service instances and bindings")
+	}
+
 	logger.Info("reconciliation succeeded")
 	return nil
 }
 
+// handleStuckResources remediates service instances and bindings stuck in deletion as requested with the remove-finalizers or retry-deletion annotation,
+// and lists the remaining stuck resources in a ConfigMap
+func (r *BtpOperatorReconciler) handleStuckResources(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	data := make(map[string]string)
+	for _, gvk := range []schema.GroupVersionKind{instanceGvk, bindingGvk} {
+		items, err := r.listResources(ctx, gvk)
+		if err != nil {
+			return err
+		}
+		stuck := make([]string, 0)
+		for i := range items {
+			item := &items[i]
+			if !r.isStuckInDeletion(item) {
+				continue
+			}
+			if strings.ToLower(item.GetAnnotations()[removeFinalizersAnnotationKey]) == "true" {
+				logger.Info(fmt.Sprintf("removing finalizers from %s %s/%s stuck in deletion", gvk.Kind, item.GetNamespace(), item.GetName()))
+				if err := r.removeStuckResourceFinalizers(ctx, item); err != nil {
+					return err
+				}
+				continue
+			}
+			if strings.ToLower(item.GetAnnotations()[retryDeletionAnnotationKey]) == "true" {
+				logger.Info(fmt.Sprintf("retrying deletion of %s %s/%s stuck in deletion", gvk.Kind, item.GetNamespace(), item.GetName()))
+				if err := r.retryStuckResourceDeletion(ctx, item); err != nil {
+					return err
+				}
+			}
+			stuck = append(stuck, describeStuckResource(item))
+		}
+		if len(stuck) > 0 {
+			logger.Info(fmt.Sprintf("%d %s resource(s) stuck in deletion", len(stuck), gvk.Kind))
+		}
+		if r.metrics != nil {
+			r.metrics.SetStuckResources(gvk.Kind, len(stuck))
+		}
+		if len(stuck) > 0 {
+			data[gvk.Kind] = strings.Join(stuck, "\n")
+		}
+	}
+
+	return r.syncStuckResourcesConfigMap(ctx, data)
+}
+
+// syncStuckResourcesConfigMap applies the stuck resources ConfigMap only when its data changes, and deletes it when nothing is stuck
+func (r *BtpOperatorReconciler) syncStuckResourcesConfigMap(ctx context.Context, data map[string]string) error {
+	current := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Name: stuckResourcesConfigMapName, Namespace: ChartNamespace}, current)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get %s ConfigMap: %w", stuckResourcesConfigMapName, err)
+	}
+	exists := err == nil
+	if len(data) == 0 {
+		if !exists {
+			return nil
+		}
+		if err := r.Delete(ctx, current); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s ConfigMap: %w", stuckResourcesConfigMapName, err)
+		}
+		return nil
+	}
+	if exists && maps.Equal(current.Data, data) {
+		return nil
+	}
+
+	reportData := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		reportData[k] = v
+	}
+	return r.applyReportConfigMap(ctx, stuckResourcesConfigMapName, reportData)
+}
+
+// checkSecretReferences reports service instances and bindings that reference missing Secrets in the SecretReferencesResolved condition.
+// The condition is removed once all referenced Secrets exist.
+func (r *BtpOperatorReconciler) checkSecretReferences(ctx context.Context, cr *v1alpha1.BtpOperator) error {
+	missing, err := r.findMissingSecretReferences(ctx)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		log.FromContext(ctx).Info(fmt.Sprintf("%d service instance(s) and binding(s) reference missing Secrets", len(missing)))
+	}
+
+	return r.updateSecretReferencesCondition(ctx, cr, missing)
+}
+
+func (r *BtpOperatorReconciler) findMissingSecretReferences(ctx context.Context) ([]string, error) {
+	type reference struct {
+		source string
+		secret client.ObjectKey
+	}
+	var references []reference
+	namespaces := make(map[string]struct{})
+	for _, gvk := range []schema.GroupVersionKind{instanceGvk, bindingGvk} {
+		items, err := r.listResources(ctx, gvk)
+		if err != nil {
+			return nil, fmt.Errorf("while listing %s resources: %w", gvk.Kind, err)
+		}
+		for i := range items {
+			item := &items[i]
+			if item.GetDeletionTimestamp() != nil {
+				continue
+			}
+			for _, ref := range secretReferences(item, r.credentialsNamespaceFromSapBtpManagerSecret) {
+				references = append(references, reference{source: fmt.Sprintf("%s %s/%s", gvk.Kind, item.GetNamespace(), item.GetName()), secret: ref})
+				namespaces[ref.Namespace] = struct{}{}
+			}
+		}
+	}
+
+	existingSecrets := make(map[client.ObjectKey]struct{})
+	for namespace := range namespaces {
+		// only the metadata of the Secrets is listed, with a single request per namespace
+		secrets := &metav1.PartialObjectMetadataList{}
+		secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(secretKind + "List"))
+		if err := r.apiServerClient.List(ctx, secrets, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("while listing Secrets in %s namespace: %w", namespace, err)
+		}
+		for _, secret := range secrets.Items {
+			existingSecrets[client.ObjectKey{Name: secret.Name, Namespace: secret.Namespace}] = struct{}{}
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, ref := range references {
+		if _, exists := existingSecrets[ref.secret]; !exists {
+			missing = append(missing, fmt.Sprintf("%s references missing Secret %s/%s", ref.source, ref.secret.Namespace, ref.secret.Name))
+		}
+	}
+	sort.Strings(missing)
+
+	return missing, nil
+}
+
+// secretReferences returns the Secrets referenced by a service instance or binding.
+// The btpAccessCredentialsSecret is read by the SAP BTP service operator from its management namespace, and the parametersFrom Secrets from the namespace of the resource.
+func secretReferences(u *unstructured.Unstructured, managementNamespace string) []client.ObjectKey {
+	var refs []client.ObjectKey
+	if name, _, _ := unstructured.NestedString(u.Object, "spec", "btpAccessCredentialsSecret"); name != "" && managementNamespace != "" {
+		refs = append(refs, client.ObjectKey{Name: name, Namespace: managementNamespace})
+	}
+	parametersFrom, _, _ := unstructured.NestedSlice(u.Object, "spec", "parametersFrom")
+	for _, p := range parametersFrom {
+		source, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(source, "secretKeyRef", "name"); name != "" {
+			refs = append(refs, client.ObjectKey{Name: name, Namespace: u.GetNamespace()})
+		}
+	}
+	return refs
+}
+
+func (r *BtpOperatorReconciler) updateSecretReferencesCondition(ctx context.Context, cr *v1alpha1.BtpOperator, missing []string) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cr), cr); err != nil {
+		return fmt.Errorf("while getting the BtpOperator: %w", err)
+	}
+	if len(missing) == 0 {
+		if !conditions.RemoveStatusCondition(&cr.Status.Conditions, conditions.SecretReferencesResolvedType) {
+			return nil
+		}
+		return r.Status().Update(ctx, cr)
+	}
+
+	message := strings.Join(missing, "; ")
+	if len(missing) > maxMissingSecretReferencesInMessage {
+		message = fmt.Sprintf("%s; and %d more", strings.Join(missing[:maxMissingSecretReferencesInMessage], "; "), len(missing)-maxMissingSecretReferencesInMessage)
+	}
+	current := meta.FindStatusCondition(conditionsWithoutNil(cr.Status.Conditions), conditions.SecretReferencesResolvedType)
+	if current != nil && current.Message == message {
+		return nil
+	}
+	conditions.SetStatusCondition(&cr.Status.Conditions, *conditions.ConditionFromExistingReason(conditions.MissingReferencedSecrets, message))
+
+	return r.Status().Update(ctx, cr)
+}
+
+func (r *BtpOperatorReconciler) isStuckInDeletion(u *unstructured.Unstructured) bool {
+	deletionTimestamp := u.GetDeletionTimestamp()
+	if deletionTimestamp == nil || len(u.GetFinalizers()) == 0 {
+		return false
+	}
+	return time.Since(deletionTimestamp.Time) >= StuckDeletionThreshold
+}
+
+func describeStuckResource(u *unstructured.Unstructured) string {
+	description := fmt.Sprintf("%s/%s deleting since %s", u.GetNamespace(), u.GetName(), u.GetDeletionTimestamp().UTC().Format(time.RFC3339))
+	statusConditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range statusConditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["status"] == string(metav1.ConditionTrue) {
+			continue
+		}
+		if message, ok := condition["message"].(string); ok && message != "" {
+			return fmt.Sprintf("%s: %s", description, message)
+		}
+	}
+	return description
+}
+
+// removeStuckResourceFinalizers lets the API server complete the deletion. The Secret of a service binding is owned by the binding,
+// so the garbage collector deletes it afterward.
+func (r *BtpOperatorReconciler) removeStuckResourceFinalizers(ctx context.Context, u *unstructured.Unstructured) error {
+	u.SetFinalizers([]string{})
+	if err := r.Update(ctx, u); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// retryStuckResourceDeletion removes the retry-deletion annotation. The update makes the SAP BTP service operator reconcile the resource again,
+// which retries its deletion in SAP BTP.
+func (r *BtpOperatorReconciler) retryStuckResourceDeletion(ctx context.Context, u *unstructured.Unstructured) error {
+	annotations := u.GetAnnotations()
+	delete(annotations, retryDeletionAnnotationKey)
+	u.SetAnnotations(annotations)
+	if err := r.Update(ctx, u); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// runResourceCountMetricsUpdates refreshes the managed resources metrics every ResourceCountMetricsInterval until the manager stops
+func (r *BtpOperatorReconciler) runResourceCountMetricsUpdates(ctx context.Context) error {
+	ctx = log.IntoContext(ctx, log.Log.WithName("resource-count-metrics"))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.refreshResourceCountMetrics(ctx)):
+		}
+	}
+}
+
+// refreshResourceCountMetrics updates the managed resources metrics, or clears them when they are disabled, and returns the time to the next refresh
+func (r *BtpOperatorReconciler) refreshResourceCountMetrics(ctx context.Context) time.Duration {
+	interval := time.Duration(resourceCountMetricsInterval.Load())
+	if interval <= 0 {
+		r.metrics.ResetManagedResources()
+		return resourceCountMetricsDisabledCheckInterval
+	}
+	r.updateResourceCountMetrics(ctx)
+	return interval
+}
+
+func (r *BtpOperatorReconciler) updateResourceCountMetrics(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	counts, err := r.countManagedResources(ctx)
+	if err != nil {
+		logger.Error(err, "while counting managed resources for metrics")
+		return
+	}
+	for kind, countsByNamespace := range counts {
+		r.metrics.SetManagedResources(kind, countsByNamespace)
+	}
+}
+
+// countManagedResources returns the number of service instances, service bindings and service binding Secrets per kind and namespace
+func (r *BtpOperatorReconciler) countManagedResources(ctx context.Context) (map[string]map[string]int, error) {
+	counts := map[string]map[string]int{
+		btpOperatorServiceInstance: {},
+		btpOperatorServiceBinding:  {},
+		secretKind:                 {},
+	}
+
+	for _, gvk := range []schema.GroupVersionKind{instanceGvk, bindingGvk} {
+		resources, err := r.listResources(ctx, gvk)
+		if err != nil {
+			return nil, fmt.Errorf("while listing %s resources: %w", gvk.Kind, err)
+		}
+		for _, resource := range resources {
+			counts[gvk.Kind][resource.GetNamespace()]++
+		}
+	}
+
+	secrets := &metav1.PartialObjectMetadataList{}
+	secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := r.apiServerClient.List(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("while listing Secrets: %w", err)
+	}
+	for _, secret := range secrets.Items {
+		if isServiceBindingSecret(&secret) {
+			counts[secretKind][secret.GetNamespace()]++
+		}
+	}
+
+	return counts, nil
+}
+
+func isServiceBindingSecret(obj metav1.Object) bool {
+	for _, ownerReference := range obj.GetOwnerReferences() {
+		if ownerReference.Kind == btpOperatorServiceBinding && strings.HasPrefix(ownerReference.APIVersion, btpOperatorGroup+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *BtpOperatorReconciler) updateReadyReplicas(ctx context.Context, cr *v1alpha1.BtpOperator) error {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKey{Name: DeploymentName, Namespace: ChartNamespace}, deployment); err != nil {
+		return fmt.Errorf("while getting %s deployment: %w", DeploymentName, err)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cr), cr); err != nil {
+		return fmt.Errorf("while getting the BtpOperator: %w", err)
+	}
+	if cr.Status.ReadyReplicas == deployment.Status.ReadyReplicas {
+		return nil
+	}
+	cr.Status.ReadyReplicas = deployment.Status.ReadyReplicas
+
+	return r.Status().Update(ctx, cr)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *BtpOperatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Config = mgr.GetConfig()
+	resourceCountMetricsInterval.Store(int64(ResourceCountMetricsInterval))
+	if r.metrics != nil {
+		if err := mgr.Add(manager.RunnableFunc(r.runResourceCountMetricsUpdates)); err != nil {
+			return err
+		}
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.BtpOperator{},
 			builder.WithPredicates(r.watchBtpOperatorUpdatePredicate())).
@@ -1452,7 +2753,7 @@ func (r *BtpOperatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		).
 		Watches(
 			&corev1.ConfigMap{},
-			handler.EnqueueRequestsFromMapFunc(r.reconcileConfig),
+			r.configEventHandler(),
 			builder.WithPredicates(r.watchConfigPredicates()),
 		).
 		Watches(
@@ -1493,7 +2794,8 @@ func (r *BtpOperatorReconciler) watchBtpOperatorUpdatePredicate() predicate.Func
 			}
 			state := newBtpOperator.GetStatus().State
 			if (state == v1alpha1.StateError || state == v1alpha1.StateWarning) && newBtpOperator.ObjectMeta.DeletionTimestamp.IsZero() {
-				return false
+				oldBtpOperator, ok := e.ObjectOld.(*v1alpha1.BtpOperator)
+				return ok && r.isUpgradeApprovalPending(oldBtpOperator) && !r.isUpgradeApprovalPending(newBtpOperator)
 			}
 
 			return true
@@ -1570,7 +2872,8 @@ func (r *BtpOperatorReconciler) watchDeploymentPredicates() predicate.Funcs {
 					oldAvailableConditionStatus = string(condition.Status)
 				}
 			}
-			return newAvailableConditionStatus != oldAvailableConditionStatus || newProgressingConditionStatus != oldProgressingConditionStatus
+			return newAvailableConditionStatus != oldAvailableConditionStatus || newProgressingConditionStatus != oldProgressingConditionStatus ||
+				newObj.Status.ReadyReplicas != oldObj.Status.ReadyReplicas
 		},
 	}
 }
@@ -1696,60 +2999,197 @@ func (r *BtpOperatorReconciler) watchMutatingWebhooksPredicates() predicate.Func
 	}
 }
 
+type configSetting struct {
+	get func() string
+	set func(string) error
+}
+
+func stringSetting(v *string) configSetting {
+	return configSetting{
+		get: func() string { return *v },
+		set: func(s string) error {
+			*v = s
+			return nil
+		},
+	}
+}
+
+func durationSetting(v *time.Duration) configSetting {
+	return configSetting{
+		get: func() string { return v.String() },
+		set: func(s string) error {
+			d, err := time.ParseDuration(s)
+			if err == nil {
+				*v = d
+			}
+			return err
+		},
+	}
+}
+
+func intSetting(v *int) configSetting {
+	return configSetting{
+		get: func() string { return strconv.Itoa(*v) },
+		set: func(s string) error {
+			i, err := strconv.Atoi(s)
+			if err == nil {
+				*v = i
+			}
+			return err
+		},
+	}
+}
+
+// resourceCountMetricsDurationSetting updates ResourceCountMetricsInterval together with the copy read by the metrics runnable
+func resourceCountMetricsDurationSetting() configSetting {
+	setting := durationSetting(&ResourceCountMetricsInterval)
+	set := setting.set
+	setting.set = func(s string) error {
+		if err := set(s); err != nil {
+			return err
+		}
+		resourceCountMetricsInterval.Store(int64(ResourceCountMetricsInterval))
+		return nil
+	}
+	return setting
+}
+
+// ReasonDurations maps the reasons of the Error state to durations, it is set from a comma-separated list of Reason=duration pairs
+type ReasonDurations map[conditions.Reason]time.Duration
+
+func (d *ReasonDurations) String() string {
+	pairs := make([]string, 0, len(*d))
+	for reason, duration := range *d {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", reason, duration))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (d *ReasonDurations) Set(s string) error {
+	parsed := ReasonDurations{}
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		reason, value, found := strings.Cut(pair, "=")
+		if !found {
+			return fmt.Errorf("expected Reason=duration, got %q", pair)
+		}
+		reason = strings.TrimSpace(reason)
+		if metadata, known := conditions.Reasons[conditions.Reason(reason)]; !known || metadata.State != v1alpha1.StateError {
+			return fmt.Errorf("%q is not a reason of the %s state", reason, v1alpha1.StateError)
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid duration for reason %s: %w", reason, err)
+		}
+		parsed[conditions.Reason(reason)] = duration
+	}
+	*d = parsed
+	return nil
+}
+
+// configSettings maps the keys of the BTP Manager ConfigMap to the settings they override
+var configSettings = map[string]configSetting{
+	"ChartNamespace":                   stringSetting(&ChartNamespace),
+	"ChartPath":                        stringSetting(&ChartPath),
+	"SecretName":                       stringSetting(&SecretName),
+	"ConfigName":                       stringSetting(&ConfigName),
+	"DeploymentName":                   stringSetting(&DeploymentName),
+	"ProcessingStateRequeueInterval":   durationSetting(&ProcessingStateRequeueInterval),
+	"ReadyStateRequeueInterval":        durationSetting(&ReadyStateRequeueInterval),
+	"ReadyTimeout":                     durationSetting(&ReadyTimeout),
+	"HardDeleteCheckInterval":          durationSetting(&HardDeleteCheckInterval),
+	"HardDeleteTimeout":                durationSetting(&HardDeleteTimeout),
+	"ResourcesPath":                    stringSetting(&ResourcesPath),
+	"ReadyCheckInterval":               durationSetting(&ReadyCheckInterval),
+	"DeleteRequestTimeout":             durationSetting(&DeleteRequestTimeout),
+	"CaCertificateExpiration":          durationSetting(&CaCertificateExpiration),
+	"WebhookCertificateExpiration":     durationSetting(&WebhookCertificateExpiration),
+	"ExpirationBoundary":               durationSetting(&ExpirationBoundary),
+	"ApplyTimeout":                     durationSetting(&ApplyTimeout),
+	"ApplyRetryCount":                  intSetting(&ApplyRetryCount),
+	"ApplyRetryInterval":               durationSetting(&ApplyRetryInterval),
+	"ErrorStateRequeueInterval":        durationSetting(&ErrorStateRequeueInterval),
+	"ErrorStateRequeueIntervals":       {get: ErrorStateRequeueIntervals.String, set: ErrorStateRequeueIntervals.Set},
+	"ForceDeleteConfirmationThreshold": intSetting(&ForceDeleteConfirmationThreshold),
+	"StuckDeletionThreshold":           durationSetting(&StuckDeletionThreshold),
+	"EnableLimitedCache":               stringSetting(&EnableLimitedCache),
+	"EnableWebhookReadinessCheck":      stringSetting(&EnableWebhookReadinessCheck),
+	"ResourceCountMetricsInterval":     resourceCountMetricsDurationSetting(),
+	"EnableUpgradeDryRun":              stringSetting(&EnableUpgradeDryRun),
+	"RsaKeyBits": {
+		get: func() string { return strconv.Itoa(certs.RsaKeyBits()) },
+		set: func(s string) error {
+			bits, err := strconv.Atoi(s)
+			if err == nil {
+				certs.SetRsaKeyBits(bits)
+			}
+			return err
+		},
+	},
+	"LogLevel": {
+		get: func() string {
+			if level := LogLevel.Level(); level < zapcore.DebugLevel {
+				return strconv.Itoa(-int(level))
+			}
+			return LogLevel.Level().String()
+		},
+		set: func(s string) error {
+			level, err := parseLogLevel(s)
+			if err == nil {
+				LogLevel.SetLevel(level)
+			}
+			return err
+		},
+	},
+	"LogFormat": {
+		get: LogFormat.String,
+		set: LogFormat.Set,
+	},
+}
+
+// configEventHandler applies the BTP Manager ConfigMap on creation and update, and restores the settings overridden by it on deletion
+func (r *BtpOperatorReconciler) configEventHandler() handler.EventHandler {
+	mapped := handler.EnqueueRequestsFromMapFunc(r.reconcileConfig)
+	return handler.Funcs{
+		CreateFunc:  mapped.Create,
+		UpdateFunc:  mapped.Update,
+		GenericFunc: mapped.Generic,
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.restoreConfig(func(string) bool { return true })
+			for _, req := range r.enqueuePrimaryBtpOperatorRequest(ctx) {
+				q.Add(req)
+			}
+		},
+	}
+}
+
 func (r *BtpOperatorReconciler) reconcileConfig(ctx context.Context, obj client.Object) []reconcile.Request {
-	logger := log.FromContext(nil, "name", obj.GetName(), "namespace", obj.GetNamespace())
+	logger := configLogger.WithValues("name", obj.GetName(), "namespace", obj.GetNamespace())
 	cm, ok := obj.(*corev1.ConfigMap)
 	if !ok {
 		return []reconcile.Request{}
 	}
 	logger.Info("reconciling config update", "config", cm.Data)
+	r.restoreConfig(func(k string) bool {
+		_, found := cm.Data[k]
+		return !found
+	})
+	if r.overriddenConfig == nil {
+		r.overriddenConfig = make(map[string]string)
+	}
 	for k, v := range cm.Data {
-		var err error
-		switch k {
-		case "ChartNamespace":
-			ChartNamespace = v
-		case "ChartPath":
-			ChartPath = v
-		case "SecretName":
-			SecretName = v
-		case "ConfigName":
-			ConfigName = v
-		case "DeploymentName":
-			DeploymentName = v
-		case "ProcessingStateRequeueInterval":
-			ProcessingStateRequeueInterval, err = time.ParseDuration(v)
-		case "ReadyStateRequeueInterval":
-			ReadyStateRequeueInterval, err = time.ParseDuration(v)
-		case "ReadyTimeout":
-			ReadyTimeout, err = time.ParseDuration(v)
-		case "HardDeleteCheckInterval":
-			HardDeleteCheckInterval, err = time.ParseDuration(v)
-		case "HardDeleteTimeout":
-			HardDeleteTimeout, err = time.ParseDuration(v)
-		case "ResourcesPath":
-			ResourcesPath = v
-		case "ReadyCheckInterval":
-			ReadyCheckInterval, err = time.ParseDuration(v)
-		case "DeleteRequestTimeout":
-			DeleteRequestTimeout, err = time.ParseDuration(v)
-		case "CaCertificateExpiration":
-			CaCertificateExpiration, err = time.ParseDuration(v)
-		case "WebhookCertificateExpiration":
-			WebhookCertificateExpiration, err = time.ParseDuration(v)
-		case "ExpirationBoundary":
-			ExpirationBoundary, err = time.ParseDuration(v)
-		case "RsaKeyBits":
-			var bits int
-			bits, err = strconv.Atoi(v)
-			if err == nil {
-				certs.SetRsaKeyBits(bits)
-			}
-		case "EnableLimitedCache":
-			EnableLimitedCache = v
-		default:
+		setting, found := configSettings[k]
+		if !found {
 			logger.Info("unknown config update key", k, v)
+			continue
 		}
-		if err != nil {
+		if _, overridden := r.overriddenConfig[k]; !overridden {
+			r.overriddenConfig[k] = setting.get()
+		}
+		if err := setting.set(v); err != nil {
 			logger.Info("failed to parse config update", k, err)
 		}
 	}
@@ -1757,6 +3197,30 @@ func (r *BtpOperatorReconciler) reconcileConfig(ctx context.Context, obj client.
 	return r.enqueuePrimaryBtpOperatorRequest(ctx)
 }
 
+// restoreConfig sets the overridden settings selected by the filter back to the values they had before the ConfigMap overrode them
+func (r *BtpOperatorReconciler) restoreConfig(filter func(key string) bool) {
+	for k, v := range r.overriddenConfig {
+		if !filter(k) {
+			continue
+		}
+		if err := configSettings[k].set(v); err != nil {
+			configLogger.Info("failed to restore config setting", k, err)
+		}
+		delete(r.overriddenConfig, k)
+	}
+}
+
+// parseLogLevel accepts the same values as the zap-log-level flag: a level name or a positive integer for debug verbosity
+func parseLogLevel(v string) (zapcore.Level, error) {
+	if verbosity, err := strconv.Atoi(v); err == nil {
+		if verbosity <= 0 {
+			return zapcore.InfoLevel, fmt.Errorf("log level verbosity must be greater than 0, got %d", verbosity)
+		}
+		return zapcore.Level(-verbosity), nil
+	}
+	return zapcore.ParseLevel(v)
+}
+
 func (r *BtpOperatorReconciler) watchConfigPredicates() predicate.Funcs {
 	nameMatches := func(o client.Object) bool { return o.GetName() == ConfigName && o.GetNamespace() == ChartNamespace }
 	return predicate.Funcs{
@@ -4,8 +4,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/kyma-project/btp-manager/internal/conditions"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
 )
 
 var _ = Describe("BTP Operator controller - configuration", func() {
@@ -44,5 +46,110 @@ var _ = Describe("BTP Operator controller - configuration", func() {
 				Expect(EnableLimitedCache).To(Equal("false"))
 			})
 		})
+
+		Context("when apply tunables are configured", func() {
+			var (
+				originalApplyTimeout               time.Duration
+				originalApplyRetryCount            int
+				originalApplyRetryInterval         time.Duration
+				originalErrorStateRequeueInterval  time.Duration
+				originalErrorStateRequeueIntervals ReasonDurations
+			)
+
+			BeforeEach(func() {
+				originalApplyTimeout = ApplyTimeout
+				originalApplyRetryCount = ApplyRetryCount
+				originalApplyRetryInterval = ApplyRetryInterval
+				originalErrorStateRequeueInterval = ErrorStateRequeueInterval
+				originalErrorStateRequeueIntervals = ErrorStateRequeueIntervals
+			})
+
+			AfterEach(func() {
+				ApplyTimeout = originalApplyTimeout
+				ApplyRetryCount = originalApplyRetryCount
+				ApplyRetryInterval = originalApplyRetryInterval
+				ErrorStateRequeueInterval = originalErrorStateRequeueInterval
+				ErrorStateRequeueIntervals = originalErrorStateRequeueIntervals
+			})
+
+			It("should set the apply timeout, retries and error state requeue interval", func() {
+				GinkgoWriter.Println("--- PROCESS:", GinkgoParallelProcess(), "---")
+
+				cm := initConfig(map[string]string{
+					"ApplyTimeout":              "3m",
+					"ApplyRetryCount":           "5",
+					"ApplyRetryInterval":        "2s",
+					"ErrorStateRequeueInterval": "30s",
+				})
+				reconciler.reconcileConfig(context.TODO(), cm)
+				Expect(ApplyTimeout).To(Equal(time.Minute * 3))
+				Expect(ApplyRetryCount).To(Equal(5))
+				Expect(ApplyRetryInterval).To(Equal(time.Second * 2))
+				Expect(ErrorStateRequeueInterval).To(Equal(time.Second * 30))
+			})
+
+			It("should keep the retry count when the value is invalid", func() {
+				GinkgoWriter.Println("--- PROCESS:", GinkgoParallelProcess(), "---")
+
+				cm := initConfig(map[string]string{"ApplyRetryCount": "many"})
+				reconciler.reconcileConfig(context.TODO(), cm)
+				Expect(ApplyRetryCount).To(Equal(originalApplyRetryCount))
+			})
+
+			It("should set the error state requeue intervals per reason", func() {
+				GinkgoWriter.Println("--- PROCESS:", GinkgoParallelProcess(), "---")
+
+				cm := initConfig(map[string]string{"ErrorStateRequeueIntervals": "ProvisioningFailed=5m, ChartInstallFailed=1m"})
+				reconciler.reconcileConfig(context.TODO(), cm)
+				Expect(ErrorStateRequeueIntervals).To(Equal(ReasonDurations{
+					conditions.ProvisioningFailed: time.Minute * 5,
+					conditions.ChartInstallFailed: time.Minute,
+				}))
+			})
+
+			It("should keep the error state requeue intervals when a reason is not an error reason", func() {
+				GinkgoWriter.Println("--- PROCESS:", GinkgoParallelProcess(), "---")
+
+				cm := initConfig(map[string]string{"ErrorStateRequeueIntervals": "ReconcileSucceeded=5m"})
+				reconciler.reconcileConfig(context.TODO(), cm)
+				Expect(ErrorStateRequeueIntervals).To(Equal(originalErrorStateRequeueIntervals))
+			})
+		})
+
+		Context("when LogLevel is configured", func() {
+			var originalLevel zapcore.Level
+
+			BeforeEach(func() {
+				originalLevel = LogLevel.Level()
+			})
+
+			AfterEach(func() {
+				LogLevel.SetLevel(originalLevel)
+			})
+
+			It("should change the log level at runtime", func() {
+				GinkgoWriter.Println("--- PROCESS:", GinkgoParallelProcess(), "---")
+
+				cm := initConfig(map[string]string{"LogLevel": "error"})
+				reconciler.reconcileConfig(context.TODO(), cm)
+				Expect(LogLevel.Level()).To(Equal(zapcore.ErrorLevel))
+			})
+
+			It("should accept debug verbosity as an integer", func() {
+				GinkgoWriter.Println("--- PROCESS:", GinkgoParallelProcess(), "---")
+
+				cm := initConfig(map[string]string{"LogLevel": "2"})
+				reconciler.reconcileConfig(context.TODO(), cm)
+				Expect(LogLevel.Level()).To(Equal(zapcore.Level(-2)))
+			})
+
+			It("should keep the current log level when the value is invalid", func() {
+				GinkgoWriter.Println("--- PROCESS:", GinkgoParallelProcess(), "---")
+
+				cm := initConfig(map[string]string{"LogLevel": "verbose"})
+				reconciler.reconcileConfig(context.TODO(), cm)
+				Expect(LogLevel.Level()).To(Equal(originalLevel))
+			})
+		})
 	})
 })
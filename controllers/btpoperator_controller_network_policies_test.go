@@ -38,6 +38,7 @@ var expectedPolicyNames = []string{
 	"kyma-project.io--btp-operator-to-dns",
 	"kyma-project.io--allow-btp-operator-metrics",
 	"kyma-project.io--btp-operator-allow-to-webhook",
+	"kyma-project.io--btp-manager-allow-to-webhook",
 }
 
 var _ = Describe("BTP Operator Network Policies", func() {
@@ -57,7 +58,7 @@ var _ = Describe("BTP Operator Network Policies", func() {
 			}
 			policies, err := reconciler.loadNetworkPolicies()
 			Expect(err).NotTo(HaveOccurred())
-			expectedPolicyCount := 4
+			expectedPolicyCount := 5
 			Expect(policies).To(HaveLen(expectedPolicyCount))
 			for _, policy := range policies {
 				Expect(policy.GetKind()).To(Equal("NetworkPolicy"))
@@ -88,7 +89,7 @@ var _ = Describe("BTP Operator Network Policies", func() {
 		It("Should load and prepare network policies", func() {
 			policies, err := reconciler.loadNetworkPolicies()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(policies).To(HaveLen(4))
+			Expect(policies).To(HaveLen(5))
 			for _, policy := range policies {
 				Expect(policy.GetKind()).To(Equal("NetworkPolicy"))
 				Expect(policy.GetAPIVersion()).To(Equal("networking.k8s.io/v1"))
@@ -148,6 +149,23 @@ var _ = Describe("BTP Operator Network Policies", func() {
 			}
 			Expect(btpOperator.IsNetworkPoliciesDisabled()).To(BeFalse())
 		})
+
+		It("Should prefer the spec over the annotation for network policies", func() {
+			enabled := true
+			btpOperator.Spec.NetworkPolicies = &v1alpha1.NetworkPoliciesSpec{Enabled: &enabled}
+			btpOperator.Annotations = map[string]string{
+				v1alpha1.DisableNetworkPoliciesAnnotation: "true",
+			}
+			Expect(btpOperator.IsNetworkPoliciesDisabled()).To(BeFalse())
+
+			enabled = false
+			btpOperator.Annotations = nil
+			Expect(btpOperator.IsNetworkPoliciesDisabled()).To(BeTrue())
+
+			btpOperator.Spec.NetworkPolicies = &v1alpha1.NetworkPoliciesSpec{}
+			Expect(btpOperator.IsNetworkPoliciesDisabled()).To(BeFalse())
+			btpOperator.Spec.NetworkPolicies = nil
+		})
 	})
 
 	Context("When testing cleanupNetworkPolicies", func() {
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("BTP Operator controller - readiness checks", Label("readiness"), func() {
+	var reconciler *BtpOperatorReconciler
+
+	newWebhookConfigurationWithCaBundle := func(kind, name string, caBundle interface{}, services ...map[string]interface{}) *unstructured.Unstructured {
+		webhooks := make([]interface{}, 0)
+		for _, service := range services {
+			webhooks = append(webhooks, map[string]interface{}{
+				"name": name,
+				"clientConfig": map[string]interface{}{
+					"service":  service,
+					"caBundle": caBundle,
+				},
+			})
+		}
+		u := &unstructured.Unstructured{Object: map[string]interface{}{"webhooks": webhooks}}
+		u.SetKind(kind)
+		u.SetName(name)
+		return u
+	}
+	newWebhookConfiguration := func(kind, name string, services ...map[string]interface{}) *unstructured.Unstructured {
+		return newWebhookConfigurationWithCaBundle(kind, name, []byte("ca"), services...)
+	}
+
+	BeforeEach(func() {
+		GinkgoWriter.Println("--- PROCESS:", GinkgoParallelProcess(), "---")
+		reconciler = &BtpOperatorReconciler{}
+	})
+
+	Context("When getting webhook server endpoints", func() {
+		It("should return unique endpoints from webhook configurations with the default port", func() {
+			service := map[string]interface{}{"name": "sap-btp-operator-webhook-service", "namespace": kymaNamespace}
+			us := []*unstructured.Unstructured{
+				newWebhookConfiguration(MutatingWebhookConfiguration, "mutating", service, service),
+				newWebhookConfiguration(ValidatingWebhookConfiguration, "validating", service),
+				{Object: map[string]interface{}{"kind": deploymentKind}},
+			}
+
+			endpoints, err := reconciler.getWebhookServerEndpoints(us)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoints).To(HaveLen(1))
+			Expect(endpoints[0].address()).To(Equal("sap-btp-operator-webhook-service.kyma-system.svc:443"))
+			Expect(endpoints[0].caBundle).To(Equal([]byte("ca")))
+		})
+
+		It("should use the port from the service reference", func() {
+			service := map[string]interface{}{"name": "webhook", "namespace": kymaNamespace, "port": int64(9443)}
+			us := []*unstructured.Unstructured{newWebhookConfiguration(ValidatingWebhookConfiguration, "validating", service)}
+
+			endpoints, err := reconciler.getWebhookServerEndpoints(us)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoints).To(HaveLen(1))
+			Expect(endpoints[0].address()).To(Equal("webhook.kyma-system.svc:9443"))
+		})
+
+		It("should accept the CA bundle as a base64 string read from the API server", func() {
+			service := map[string]interface{}{"name": "webhook", "namespace": kymaNamespace}
+			us := []*unstructured.Unstructured{
+				newWebhookConfigurationWithCaBundle(ValidatingWebhookConfiguration, "validating", base64.StdEncoding.EncodeToString([]byte("ca")), service),
+			}
+
+			endpoints, err := reconciler.getWebhookServerEndpoints(us)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoints).To(HaveLen(1))
+			Expect(endpoints[0].caBundle).To(Equal([]byte("ca")))
+		})
+
+		It("should return an error for a CA bundle of an unexpected type", func() {
+			service := map[string]interface{}{"name": "webhook", "namespace": kymaNamespace}
+			us := []*unstructured.Unstructured{newWebhookConfigurationWithCaBundle(ValidatingWebhookConfiguration, "validating", int64(1), service)}
+
+			_, err := reconciler.getWebhookServerEndpoints(us)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When checking the webhook server TLS handshake", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		serverEndpoint := func(caBundle []byte) webhookServerEndpoint {
+			host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			portNumber, err := strconv.ParseInt(port, 10, 64)
+			Expect(err).NotTo(HaveOccurred())
+			return webhookServerEndpoint{host: host, port: portNumber, caBundle: caBundle}
+		}
+
+		It("should succeed when the server certificate is signed by the CA bundle", func() {
+			caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+			Expect(reconciler.checkWebhookServerHandshake(ctx, serverEndpoint(caBundle))).To(Succeed())
+		})
+
+		It("should fail when the server certificate is not signed by the CA bundle", func() {
+			otherServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+			defer otherServer.Close()
+			caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: otherServer.Certificate().Raw})
+
+			Expect(reconciler.checkWebhookServerHandshake(ctx, serverEndpoint(caBundle))).NotTo(Succeed())
+		})
+
+		It("should fail when the CA bundle is invalid", func() {
+			Expect(reconciler.checkWebhookServerHandshake(ctx, serverEndpoint([]byte("ca")))).NotTo(Succeed())
+		})
+	})
+})
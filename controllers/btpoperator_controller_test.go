@@ -2,16 +2,34 @@ package controllers
 
 import (
 	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/kyma-project/btp-manager/api/v1alpha1"
 	"github.com/kyma-project/btp-manager/internal/conditions"
+	"github.com/kyma-project/btp-manager/internal/logformat"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -176,3 +194,1030 @@ func TestBtpOperatorReconciler_UpdateBtpOperatorStatus(t *testing.T) {
 		assert.True(t, currentBtpOperator.IsMsgForGivenReasonEqual(string(conditions.ReconcileSucceeded), conditionMsg3))
 	})
 }
+
+func TestBtpOperatorReconciler_ApplyOrUpdateResources(t *testing.T) {
+	ctx := context.Background()
+	originalApplyRetryCount, originalApplyRetryInterval := ApplyRetryCount, ApplyRetryInterval
+	defer func() { ApplyRetryCount, ApplyRetryInterval = originalApplyRetryCount, originalApplyRetryInterval }()
+	ApplyRetryInterval = time.Millisecond
+	existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: kymaNamespace}}
+	newConfigMap := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind(configMapKind)
+		u.SetName(existing.Name)
+		u.SetNamespace(existing.Namespace)
+		return u
+	}
+	failingUpdates := func(updateErrors ...error) (interceptor.Funcs, *int) {
+		calls := 0
+		return interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				calls++
+				if calls <= len(updateErrors) {
+					return updateErrors[calls-1]
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}, &calls
+	}
+	conflictErr := k8serrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, existing.Name, errors.New("conflict"))
+
+	t.Run("should retry transient errors", func(t *testing.T) {
+		// given
+		ApplyRetryCount = 3
+		funcs, calls := failingUpdates(conflictErr, conflictErr)
+		fakeK8sClient := newFakeClient(clientgoscheme.Scheme, funcs, existing.DeepCopy())
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, clientgoscheme.Scheme, nil, nil)
+
+		// when
+		err := btpOperatorReconciler.applyOrUpdateResources(ctx, []*unstructured.Unstructured{newConfigMap()})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("should return error when retries are exhausted", func(t *testing.T) {
+		// given
+		ApplyRetryCount = 1
+		funcs, calls := failingUpdates(conflictErr, conflictErr, conflictErr)
+		fakeK8sClient := newFakeClient(clientgoscheme.Scheme, funcs, existing.DeepCopy())
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, clientgoscheme.Scheme, nil, nil)
+
+		// when
+		err := btpOperatorReconciler.applyOrUpdateResources(ctx, []*unstructured.Unstructured{newConfigMap()})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, k8serrors.IsConflict(err))
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("should not retry permanent errors", func(t *testing.T) {
+		// given
+		ApplyRetryCount = 3
+		funcs, calls := failingUpdates(k8serrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, existing.Name, errors.New("forbidden")))
+		fakeK8sClient := newFakeClient(clientgoscheme.Scheme, funcs, existing.DeepCopy())
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, clientgoscheme.Scheme, nil, nil)
+
+		// when
+		err := btpOperatorReconciler.applyOrUpdateResources(ctx, []*unstructured.Unstructured{newConfigMap()})
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, 1, *calls)
+	})
+}
+
+func TestBtpOperatorReconciler_WaitForWebhookServerReadiness(t *testing.T) {
+	originalReadyTimeout, originalReadyCheckInterval := ReadyTimeout, ReadyCheckInterval
+	defer func() { ReadyTimeout, ReadyCheckInterval = originalReadyTimeout, originalReadyCheckInterval }()
+	ReadyTimeout, ReadyCheckInterval = time.Hour, time.Minute
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+	webhookConfig := &unstructured.Unstructured{}
+	webhookConfig.SetAPIVersion("admissionregistration.k8s.io/v1")
+	webhookConfig.SetKind(ValidatingWebhookConfiguration)
+	webhookConfig.SetName(validatingWebhookName)
+	webhookConfig.Object["webhooks"] = []interface{}{map[string]interface{}{
+		"name": "vserviceinstance.kb.io",
+		"clientConfig": map[string]interface{}{
+			"caBundle": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+			"service":  map[string]interface{}{"name": "unreachable-webhook-service", "namespace": ChartNamespace},
+		},
+	}}
+	fakeK8sClient := newFakeClient(clientgoscheme.Scheme, interceptor.Funcs{})
+	btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, clientgoscheme.Scheme, nil, nil)
+
+	t.Run("should stop waiting when the context is cancelled", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		start := time.Now()
+
+		// when
+		err := btpOperatorReconciler.waitForWebhookServerReadiness(ctx, []*unstructured.Unstructured{webhookConfig})
+
+		// then
+		require.Error(t, err)
+		assert.Less(t, time.Since(start), ReadyCheckInterval)
+	})
+}
+
+func TestBtpOperatorReconciler_HandleErrorState(t *testing.T) {
+	ctx := context.Background()
+	scheme := clientgoscheme.Scheme
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	StatusUpdateTimeout = statusUpdateTimeout
+	StatusUpdateCheckInterval = statusUpdateCheckInterval
+	defer func() {
+		ErrorStateRequeueInterval = 0
+		ErrorStateRequeueIntervals = ReasonDurations{}
+	}()
+
+	newErrorBtpOperator := func() *v1alpha1.BtpOperator {
+		btpOperator := createDefaultBtpOperator()
+		btpOperator.Status.State = v1alpha1.StateError
+		btpOperator.Status.Conditions = []*metav1.Condition{{
+			Type:               conditions.ReadyType,
+			Status:             metav1.ConditionFalse,
+			Reason:             string(conditions.ProvisioningFailed),
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+		}}
+		return btpOperator
+	}
+
+	t.Run("should requeue without changing the state before the error state requeue interval passes", func(t *testing.T) {
+		// given
+		ErrorStateRequeueInterval = time.Minute
+		btpOperator := newErrorBtpOperator()
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, btpOperator)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		result, err := btpOperatorReconciler.HandleErrorState(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		assert.Greater(t, result.RequeueAfter, time.Duration(0))
+		assert.LessOrEqual(t, result.RequeueAfter, time.Minute)
+		assert.Equal(t, v1alpha1.StateError, btpOperator.Status.State)
+	})
+
+	t.Run("should move to processing state after the error state requeue interval passes", func(t *testing.T) {
+		// given
+		ErrorStateRequeueInterval = time.Minute
+		btpOperator := newErrorBtpOperator()
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, btpOperator)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+		btpOperatorReconciler.errorStateEnteredAt = time.Now().Add(-2 * time.Minute)
+
+		// when
+		result, err := btpOperatorReconciler.HandleErrorState(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), result.RequeueAfter)
+		currentBtpOperator := &v1alpha1.BtpOperator{}
+		require.NoError(t, fakeK8sClient.Get(ctx, client.ObjectKeyFromObject(btpOperator), currentBtpOperator))
+		assert.Equal(t, v1alpha1.StateProcessing, currentBtpOperator.Status.State)
+	})
+
+	t.Run("should use the requeue interval configured for the reason of the error", func(t *testing.T) {
+		// given
+		ErrorStateRequeueInterval = 0
+		ErrorStateRequeueIntervals = ReasonDurations{conditions.ProvisioningFailed: time.Hour}
+		btpOperator := newErrorBtpOperator()
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, btpOperator)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+		btpOperatorReconciler.errorStateEnteredAt = time.Now().Add(-2 * time.Minute)
+
+		// when
+		result, err := btpOperatorReconciler.HandleErrorState(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		assert.Greater(t, result.RequeueAfter, 57*time.Minute)
+		assert.LessOrEqual(t, result.RequeueAfter, 58*time.Minute)
+		assert.Equal(t, v1alpha1.StateError, btpOperator.Status.State)
+	})
+
+	t.Run("should fall back to the error state requeue interval for other reasons", func(t *testing.T) {
+		// given
+		ErrorStateRequeueInterval = time.Minute
+		ErrorStateRequeueIntervals = ReasonDurations{conditions.ChartInstallFailed: time.Hour}
+		btpOperator := newErrorBtpOperator()
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, btpOperator)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+		btpOperatorReconciler.errorStateEnteredAt = time.Now().Add(-2 * time.Minute)
+
+		// when
+		result, err := btpOperatorReconciler.HandleErrorState(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), result.RequeueAfter)
+		currentBtpOperator := &v1alpha1.BtpOperator{}
+		require.NoError(t, fakeK8sClient.Get(ctx, client.ObjectKeyFromObject(btpOperator), currentBtpOperator))
+		assert.Equal(t, v1alpha1.StateProcessing, currentBtpOperator.Status.State)
+	})
+
+	t.Run("should apply the requeue interval again after the next transition to the error state", func(t *testing.T) {
+		// given
+		ErrorStateRequeueInterval = time.Minute
+		ErrorStateRequeueIntervals = ReasonDurations{}
+		btpOperator := newErrorBtpOperator()
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, btpOperator)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+		btpOperatorReconciler.errorStateEnteredAt = time.Now().Add(-2 * time.Minute)
+		result, err := btpOperatorReconciler.HandleErrorState(ctx, btpOperator)
+		require.NoError(t, err)
+		require.Equal(t, time.Duration(0), result.RequeueAfter)
+		require.NoError(t, btpOperatorReconciler.UpdateBtpOperatorStatus(ctx, btpOperator, v1alpha1.StateError, conditions.ProvisioningFailed, "provisioning failed again"))
+		readyCondition := meta.FindStatusCondition(conditionsWithoutNil(btpOperator.Status.Conditions), conditions.ReadyType)
+		require.NotNil(t, readyCondition)
+		require.Less(t, readyCondition.LastTransitionTime.Time, time.Now().Add(-time.Minute))
+
+		// when
+		result, err = btpOperatorReconciler.HandleErrorState(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		assert.Greater(t, result.RequeueAfter, 59*time.Second)
+		assert.LessOrEqual(t, result.RequeueAfter, time.Minute)
+		assert.Equal(t, v1alpha1.StateError, btpOperator.Status.State)
+	})
+}
+
+func TestReasonDurations_Set(t *testing.T) {
+	t.Run("should parse Reason=duration pairs", func(t *testing.T) {
+		durations := ReasonDurations{}
+
+		require.NoError(t, durations.Set("ProvisioningFailed=5m, ChartInstallFailed=30s,"))
+
+		assert.Equal(t, ReasonDurations{conditions.ProvisioningFailed: 5 * time.Minute, conditions.ChartInstallFailed: 30 * time.Second}, durations)
+		assert.Equal(t, "ChartInstallFailed=30s,ProvisioningFailed=5m0s", durations.String())
+	})
+
+	t.Run("should clear the durations with an empty value", func(t *testing.T) {
+		durations := ReasonDurations{conditions.ProvisioningFailed: time.Minute}
+
+		require.NoError(t, durations.Set(""))
+
+		assert.Empty(t, durations)
+	})
+
+	for name, value := range map[string]string{
+		"missing duration": "ProvisioningFailed",
+		"invalid duration": "ProvisioningFailed=soon",
+		"unknown reason":   "Unknown=1m",
+		"non-error reason": "ReconcileSucceeded=1m",
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			durations := ReasonDurations{conditions.ProvisioningFailed: time.Minute}
+
+			require.Error(t, durations.Set(value))
+
+			assert.Equal(t, ReasonDurations{conditions.ProvisioningFailed: time.Minute}, durations)
+		})
+	}
+}
+
+func TestBtpOperatorReconciler_CheckForceDeleteConfirmation(t *testing.T) {
+	ctx := context.Background()
+	scheme := clientgoscheme.Scheme
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	StatusUpdateTimeout = statusUpdateTimeout
+	StatusUpdateCheckInterval = statusUpdateCheckInterval
+	defer func() { ForceDeleteConfirmationThreshold = 0 }()
+	ForceDeleteConfirmationThreshold = 1
+
+	serviceResources := func() []client.Object {
+		return []client.Object{newCrd(instanceGvk), newCrd(bindingGvk),
+			resourceFixture(instanceGvk, "default", "instance-1"),
+			resourceFixture(instanceGvk, "team-a", "instance-2"),
+			resourceFixture(bindingGvk, "default", "binding-1")}
+	}
+
+	t.Run("should require confirmation and store the inventory when the threshold is exceeded", func(t *testing.T) {
+		// given
+		btpOperator := createDefaultBtpOperator()
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, append(serviceResources(), btpOperator)...)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		confirmationRequired, err := btpOperatorReconciler.checkForceDeleteConfirmation(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, confirmationRequired)
+		assert.Equal(t, v1alpha1.StateWarning, btpOperator.Status.State)
+		assert.True(t, btpOperator.IsReasonStringEqual(string(conditions.ForceDeleteConfirmationRequired)))
+
+		inventory := &corev1.ConfigMap{}
+		require.NoError(t, fakeK8sClient.Get(ctx, client.ObjectKey{Name: deletionInventoryConfigMapName, Namespace: ChartNamespace}, inventory))
+		assert.ElementsMatch(t, []string{"default/instance-1", "team-a/instance-2"}, strings.Split(inventory.Data["serviceInstances"], "\n"))
+		assert.Equal(t, "default/binding-1", inventory.Data["serviceBindings"])
+		assert.Equal(t, operatorName, inventory.Labels[managedByLabelKey])
+	})
+
+	t.Run("should not require confirmation when the force delete is confirmed", func(t *testing.T) {
+		// given
+		btpOperator := createDefaultBtpOperator()
+		btpOperator.SetAnnotations(map[string]string{v1alpha1.ForceDeleteConfirmedAnnotation: "true"})
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, append(serviceResources(), btpOperator)...)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		confirmationRequired, err := btpOperatorReconciler.checkForceDeleteConfirmation(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, confirmationRequired)
+		assert.Empty(t, btpOperator.Status.State)
+	})
+
+	t.Run("should not require confirmation below the threshold", func(t *testing.T) {
+		// given
+		ForceDeleteConfirmationThreshold = 3
+		btpOperator := createDefaultBtpOperator()
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, append(serviceResources(), btpOperator)...)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		confirmationRequired, err := btpOperatorReconciler.checkForceDeleteConfirmation(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, confirmationRequired)
+	})
+
+	t.Run("should delete the inventory together with the module resources", func(t *testing.T) {
+		// given
+		btpOperator := createDefaultBtpOperator()
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, append(serviceResources(), btpOperator)...)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+		_, err := btpOperatorReconciler.checkForceDeleteConfirmation(ctx, btpOperator)
+		require.NoError(t, err)
+
+		// when
+		err = btpOperatorReconciler.cleanupReportConfigMaps(ctx)
+
+		// then
+		require.NoError(t, err)
+		err = fakeK8sClient.Get(ctx, client.ObjectKey{Name: deletionInventoryConfigMapName, Namespace: ChartNamespace}, &corev1.ConfigMap{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+}
+
+func TestBtpOperatorReconciler_HandleStuckResources(t *testing.T) {
+	ctx := context.Background()
+	scheme := clientgoscheme.Scheme
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	newDeletingResource := func(gvk schema.GroupVersionKind, name string, deletingFor time.Duration, annotations map[string]string) *unstructured.Unstructured {
+		u := resourceFixture(gvk, "default", name)
+		u.SetFinalizers([]string{"services.cloud.sap.com/sap-btp-finalizer"})
+		u.SetAnnotations(annotations)
+		deletionTimestamp := metav1.NewTime(time.Now().Add(-deletingFor))
+		u.SetDeletionTimestamp(&deletionTimestamp)
+		return u
+	}
+	getResource := func(c client.Client, gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		return u, c.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, u)
+	}
+	configMapPatches := func(patches *int) interceptor.Funcs {
+		return interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if obj.GetObjectKind().GroupVersionKind().Kind == configMapKind {
+					*patches++
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}
+	}
+
+	t.Run("should remediate the annotated resources and report the remaining stuck resources", func(t *testing.T) {
+		// given
+		remediatedBinding := newDeletingResource(bindingGvk, "remediated-binding", 2*time.Hour, map[string]string{removeFinalizersAnnotationKey: "true"})
+		retriedInstance := newDeletingResource(instanceGvk, "retried-instance", 2*time.Hour, map[string]string{retryDeletionAnnotationKey: "true"})
+		stuckInstance := newDeletingResource(instanceGvk, "stuck-instance", 2*time.Hour, nil)
+		require.NoError(t, unstructured.SetNestedSlice(stuckInstance.Object, []interface{}{
+			map[string]interface{}{"type": "Failed", "status": "False", "message": "broker returned 500"},
+		}, "status", "conditions"))
+		patches := 0
+		fakeK8sClient := newFakeClient(scheme, configMapPatches(&patches),
+			newCrd(instanceGvk), newCrd(bindingGvk),
+			stuckInstance,
+			retriedInstance,
+			newDeletingResource(instanceGvk, "recently-deleted-instance", time.Minute, nil),
+			remediatedBinding,
+		)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		err := btpOperatorReconciler.handleStuckResources(ctx)
+
+		// then
+		require.NoError(t, err)
+
+		report := &corev1.ConfigMap{}
+		require.NoError(t, fakeK8sClient.Get(ctx, client.ObjectKey{Name: stuckResourcesConfigMapName, Namespace: ChartNamespace}, report))
+		assert.Contains(t, report.Data[btpOperatorServiceInstance], "default/stuck-instance deleting since")
+		assert.Contains(t, report.Data[btpOperatorServiceInstance], "broker returned 500")
+		assert.Contains(t, report.Data[btpOperatorServiceInstance], "default/retried-instance deleting since")
+		assert.NotContains(t, report.Data[btpOperatorServiceInstance], "recently-deleted-instance")
+		assert.NotContains(t, report.Data, btpOperatorServiceBinding)
+		assert.Equal(t, operatorName, report.Labels[managedByLabelKey])
+
+		_, err = getResource(fakeK8sClient, bindingGvk, "remediated-binding")
+		assert.True(t, k8serrors.IsNotFound(err))
+		instance, err := getResource(fakeK8sClient, instanceGvk, "retried-instance")
+		require.NoError(t, err)
+		assert.NotContains(t, instance.GetAnnotations(), retryDeletionAnnotationKey)
+		assert.NotEmpty(t, instance.GetFinalizers())
+
+		// when
+		err = btpOperatorReconciler.handleStuckResources(ctx)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, patches)
+
+		require.NoError(t, btpOperatorReconciler.cleanupReportConfigMaps(ctx))
+		err = fakeK8sClient.Get(ctx, client.ObjectKey{Name: stuckResourcesConfigMapName, Namespace: ChartNamespace}, &corev1.ConfigMap{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("should delete the report when nothing is stuck anymore", func(t *testing.T) {
+		// given
+		stuckInstance := newDeletingResource(instanceGvk, "stuck-instance", 2*time.Hour, nil)
+		patches := 0
+		fakeK8sClient := newFakeClient(scheme, configMapPatches(&patches), newCrd(instanceGvk), newCrd(bindingGvk), stuckInstance)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+		require.NoError(t, btpOperatorReconciler.handleStuckResources(ctx))
+		require.NoError(t, fakeK8sClient.Get(ctx, client.ObjectKey{Name: stuckResourcesConfigMapName, Namespace: ChartNamespace}, &corev1.ConfigMap{}))
+		instance, err := getResource(fakeK8sClient, instanceGvk, "stuck-instance")
+		require.NoError(t, err)
+		instance.SetFinalizers(nil)
+		require.NoError(t, fakeK8sClient.Update(ctx, instance))
+
+		// when
+		err = btpOperatorReconciler.handleStuckResources(ctx)
+
+		// then
+		require.NoError(t, err)
+		err = fakeK8sClient.Get(ctx, client.ObjectKey{Name: stuckResourcesConfigMapName, Namespace: ChartNamespace}, &corev1.ConfigMap{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Equal(t, 1, patches)
+	})
+}
+
+func TestBtpOperatorReconciler_CheckUpgrade(t *testing.T) {
+	ctx := context.Background()
+	scheme := clientgoscheme.Scheme
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	crdName := "serviceinstances.services.cloud.sap.com"
+	newServiceInstanceCrd := func(specProperties ...string) *apiextensionsv1.CustomResourceDefinition {
+		properties := map[string]apiextensionsv1.JSONSchemaProps{}
+		for _, p := range specProperties {
+			properties[p] = apiextensionsv1.JSONSchemaProps{Type: "string"}
+		}
+		return &apiextensionsv1.CustomResourceDefinition{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: customResourceDefinitionKind},
+			ObjectMeta: metav1.ObjectMeta{Name: crdName},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: btpOperatorGroup,
+				Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: btpOperatorServiceInstance},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+					Name:   btpOperatorApiVer,
+					Served: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{"spec": {Type: "object", Properties: properties}},
+					}},
+				}},
+			},
+		}
+	}
+	newWebhookConfiguration := func(webhooks ...interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("admissionregistration.k8s.io/v1")
+		u.SetKind(ValidatingWebhookConfiguration)
+		u.SetName(validatingWebhookName)
+		require.NoError(t, unstructured.SetNestedSlice(u.Object, webhooks, "webhooks"))
+		return u
+	}
+	installedResources := func() []client.Object {
+		instance := resourceFixture(instanceGvk, "default", "instance-1")
+		require.NoError(t, unstructured.SetNestedField(instance.Object, "parameters", "spec", "customTags"))
+		return []client.Object{newServiceInstanceCrd("serviceOfferingName", "customTags"), instance, newDeployment("0.1.0"),
+			newWebhookConfiguration(map[string]interface{}{"name": "vserviceinstance.kb.io", "failurePolicy": "Fail"})}
+	}
+	resourcesToApply := func() []*unstructured.Unstructured {
+		crd, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newServiceInstanceCrd("serviceOfferingName"))
+		require.NoError(t, err)
+		return []*unstructured.Unstructured{
+			newDeployment("0.2.0"),
+			{Object: crd},
+			newWebhookConfiguration(
+				map[string]interface{}{"name": "vserviceinstance.kb.io", "failurePolicy": "Ignore"},
+				map[string]interface{}{"name": "vservicebinding.kb.io", "failurePolicy": "Fail"},
+			),
+		}
+	}
+	expectedFindings := []string{
+		"CRD serviceinstances.services.cloud.sap.com: field spec.customTags removed from version v1, used by 1 existing resources",
+		"ValidatingWebhookConfiguration sap-btp-operator-validating-webhook-configuration: webhook vservicebinding.kb.io added",
+		"ValidatingWebhookConfiguration sap-btp-operator-validating-webhook-configuration: webhook vserviceinstance.kb.io failure policy changed from Fail to Ignore",
+	}
+
+	t.Run("should publish the findings and block the upgrade with the manual upgrade policy", func(t *testing.T) {
+		// given
+		btpOperator := createDefaultBtpOperator()
+		btpOperator.Spec.UpgradePolicy = v1alpha1.UpgradePolicyManual
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, append(installedResources(), btpOperator)...)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		err := btpOperatorReconciler.checkUpgrade(ctx, btpOperator, resourcesToApply())
+
+		// then
+		require.Error(t, err)
+		require.NotNil(t, errorWithReasonFrom(err))
+		assert.Equal(t, conditions.UpgradeApprovalRequired, errorWithReasonFrom(err).reason)
+		require.NotNil(t, btpOperator.Status.UpgradeCheck)
+		assert.Equal(t, "0.1.0", btpOperator.Status.UpgradeCheck.FromVersion)
+		assert.Equal(t, "0.2.0", btpOperator.Status.UpgradeCheck.ToVersion)
+		assert.ElementsMatch(t, expectedFindings, btpOperator.Status.UpgradeCheck.Findings)
+	})
+
+	t.Run("should allow the upgrade approved with the annotation", func(t *testing.T) {
+		// given
+		btpOperator := createDefaultBtpOperator()
+		btpOperator.Spec.UpgradePolicy = v1alpha1.UpgradePolicyManual
+		btpOperator.SetAnnotations(map[string]string{v1alpha1.UpgradeApprovedVersionAnnotation: "0.2.0"})
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, append(installedResources(), btpOperator)...)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		err := btpOperatorReconciler.checkUpgrade(ctx, btpOperator, resourcesToApply())
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, btpOperator.Status.UpgradeCheck)
+		assert.ElementsMatch(t, expectedFindings, btpOperator.Status.UpgradeCheck.Findings)
+	})
+
+	t.Run("should skip the check when the version does not change", func(t *testing.T) {
+		// given
+		btpOperator := createDefaultBtpOperator()
+		btpOperator.Spec.UpgradePolicy = v1alpha1.UpgradePolicyManual
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, append(installedResources(), btpOperator)...)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+		resources := resourcesToApply()
+		resources[0] = newDeployment("0.1.0")
+
+		// when
+		err := btpOperatorReconciler.checkUpgrade(ctx, btpOperator, resources)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, btpOperator.Status.UpgradeCheck)
+	})
+
+	t.Run("should clear the upgrade check after the upgrade", func(t *testing.T) {
+		// given
+		btpOperator := createDefaultBtpOperator()
+		btpOperator.Status.UpgradeCheck = &v1alpha1.UpgradeCheck{FromVersion: "0.0.1", ToVersion: "0.1.0", Findings: []string{"finding"}}
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, append(installedResources(), btpOperator)...)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+		resources := resourcesToApply()
+		resources[0] = newDeployment("0.1.0")
+
+		// when
+		err := btpOperatorReconciler.checkUpgrade(ctx, btpOperator, resources)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, btpOperator.Status.UpgradeCheck)
+		stored := &v1alpha1.BtpOperator{}
+		require.NoError(t, fakeK8sClient.Get(ctx, client.ObjectKeyFromObject(btpOperator), stored))
+		assert.Nil(t, stored.Status.UpgradeCheck)
+	})
+
+	t.Run("should ignore the rule fields defaulted by the API server", func(t *testing.T) {
+		// given
+		rule := func(withScope bool) map[string]interface{} {
+			r := map[string]interface{}{
+				"operations":  []interface{}{"CREATE", "UPDATE"},
+				"apiGroups":   []interface{}{btpOperatorGroup},
+				"apiVersions": []interface{}{btpOperatorApiVer},
+				"resources":   []interface{}{"serviceinstances"},
+			}
+			if withScope {
+				r["scope"] = "*"
+			}
+			return r
+		}
+		installed := newWebhookConfiguration(map[string]interface{}{"name": "vserviceinstance.kb.io", "rules": []interface{}{rule(true)}})
+		target := newWebhookConfiguration(map[string]interface{}{"name": "vserviceinstance.kb.io", "rules": []interface{}{rule(false)}})
+		fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{}, installed)
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		findings, err := btpOperatorReconciler.webhookUpgradeFindings(ctx, target)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+}
+
+func TestRemovedSchemaFields(t *testing.T) {
+	// given
+	schema := func(itemProperties, valueProperties []string) *apiextensionsv1.JSONSchemaProps {
+		properties := func(names []string) map[string]apiextensionsv1.JSONSchemaProps {
+			props := map[string]apiextensionsv1.JSONSchemaProps{}
+			for _, name := range names {
+				props[name] = apiextensionsv1.JSONSchemaProps{Type: "string"}
+			}
+			return props
+		}
+		return &apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"parametersFrom": {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+					Schema: &apiextensionsv1.JSONSchemaProps{Type: "object", Properties: properties(itemProperties)},
+				}},
+				"secretRefs": {Type: "object", AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+					Allows: true,
+					Schema: &apiextensionsv1.JSONSchemaProps{Type: "object", Properties: properties(valueProperties)},
+				}},
+			},
+		}
+	}
+	installed := schema([]string{"secretKeyRef", "configMapKeyRef"}, []string{"name", "key"})
+	target := schema([]string{"secretKeyRef"}, []string{"name"})
+	resource := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"parametersFrom": []interface{}{
+				map[string]interface{}{"secretKeyRef": "a"},
+				map[string]interface{}{"configMapKeyRef": "b"},
+			},
+			"secretRefs": map[string]interface{}{"first": map[string]interface{}{"name": "c"}},
+		},
+	}
+
+	// when
+	removed := removedSchemaFields(installed, target, []string{"spec"})
+
+	// then
+	require.Len(t, removed, 2)
+	assert.Equal(t, "spec.parametersFrom[].configMapKeyRef", schemaFieldPath(removed[0]))
+	assert.Equal(t, "spec.secretRefs.*.key", schemaFieldPath(removed[1]))
+	assert.True(t, isSchemaFieldSet(resource, removed[0]))
+	assert.False(t, isSchemaFieldSet(resource, removed[1]))
+}
+
+func TestBtpOperatorReconciler_DryRunUpgrade(t *testing.T) {
+	ctx := context.Background()
+	scheme := clientgoscheme.Scheme
+
+	newClusterRole := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("rbac.authorization.k8s.io/v1")
+		u.SetKind("ClusterRole")
+		u.SetName("sap-btp-operator-manager-role")
+		u.SetNamespace(ChartNamespace)
+		return u
+	}
+	dryRunPatches := func(patchErr error, dryRunNamespaces map[string]string) interceptor.Funcs {
+		return interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patchOptions := &client.PatchOptions{}
+				patchOptions.ApplyOptions(opts)
+				if len(patchOptions.DryRun) == 0 {
+					return errors.New("unexpected patch without dry run")
+				}
+				dryRunNamespaces[obj.GetObjectKind().GroupVersionKind().Kind] = obj.GetNamespace()
+				return patchErr
+			},
+		}
+	}
+
+	t.Run("should dry-run the resources against the live ones", func(t *testing.T) {
+		// given
+		dryRunNamespaces := map[string]string{}
+		fakeK8sClient := newFakeClient(scheme, dryRunPatches(nil, dryRunNamespaces), newDeployment("0.1.0"))
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		err := btpOperatorReconciler.dryRunUpgrade(ctx, []*unstructured.Unstructured{newDeployment("0.2.0"), newClusterRole()})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{deploymentKind: ChartNamespace, "ClusterRole": ChartNamespace}, dryRunNamespaces)
+
+		deployment := &unstructured.Unstructured{}
+		deployment.SetGroupVersionKind(newDeployment("").GroupVersionKind())
+		require.NoError(t, fakeK8sClient.Get(ctx, client.ObjectKey{Name: DeploymentName, Namespace: ChartNamespace}, deployment))
+		assert.Equal(t, "0.1.0", deployment.GetLabels()[chartVersionKey])
+		namespaces := &corev1.NamespaceList{}
+		require.NoError(t, fakeK8sClient.List(ctx, namespaces))
+		assert.Empty(t, namespaces.Items)
+	})
+
+	t.Run("should dry-run resources with a binary CA bundle", func(t *testing.T) {
+		// given
+		dryRunNamespaces := map[string]string{}
+		fakeK8sClient := newFakeClient(scheme, dryRunPatches(nil, dryRunNamespaces), newDeployment("0.1.0"))
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+		webhookConfig := &unstructured.Unstructured{}
+		webhookConfig.SetAPIVersion("admissionregistration.k8s.io/v1")
+		webhookConfig.SetKind(ValidatingWebhookConfiguration)
+		webhookConfig.SetName(validatingWebhookName)
+		webhookConfig.Object["webhooks"] = []interface{}{
+			map[string]interface{}{"name": "vserviceinstance.kb.io", "clientConfig": map[string]interface{}{"caBundle": []byte("ca")}},
+		}
+
+		// when
+		err := btpOperatorReconciler.dryRunUpgrade(ctx, []*unstructured.Unstructured{newDeployment("0.2.0"), webhookConfig})
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail with the reason when the dry run is rejected", func(t *testing.T) {
+		// given
+		fakeK8sClient := newFakeClient(scheme, dryRunPatches(k8serrors.NewBadRequest("denied by webhook"), map[string]string{}), newDeployment("0.1.0"))
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		err := btpOperatorReconciler.dryRunUpgrade(ctx, []*unstructured.Unstructured{newDeployment("0.2.0")})
+
+		// then
+		require.Error(t, err)
+		require.NotNil(t, errorWithReasonFrom(err))
+		assert.Equal(t, conditions.UpgradeDryRunFailed, errorWithReasonFrom(err).reason)
+		assert.Contains(t, err.Error(), "denied by webhook")
+	})
+
+	t.Run("should skip the dry run when the version does not change", func(t *testing.T) {
+		// given
+		dryRunNamespaces := map[string]string{}
+		fakeK8sClient := newFakeClient(scheme, dryRunPatches(nil, dryRunNamespaces), newDeployment("0.1.0"))
+		btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+		// when
+		err := btpOperatorReconciler.dryRunUpgrade(ctx, []*unstructured.Unstructured{newDeployment("0.1.0")})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, dryRunNamespaces)
+	})
+}
+
+func TestBtpOperatorReconciler_CountManagedResources(t *testing.T) {
+	ctx := context.Background()
+	scheme := clientgoscheme.Scheme
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	binding := resourceFixture(bindingGvk, "team-a", "binding-1")
+	binding.SetUID("binding-1-uid")
+
+	// given
+	fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{},
+		newCrd(instanceGvk), newCrd(bindingGvk),
+		resourceFixture(instanceGvk, "team-a", "instance-1"),
+		resourceFixture(instanceGvk, "team-a", "instance-2"),
+		resourceFixture(instanceGvk, "team-b", "instance-3"),
+		binding,
+		newSecret("team-a", "binding-1", newBindingOwnerReference(binding)),
+		newSecret("team-a", "unrelated"),
+	)
+	btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+
+	// when
+	counts, err := btpOperatorReconciler.countManagedResources(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"team-a": 2, "team-b": 1}, counts[btpOperatorServiceInstance])
+	assert.Equal(t, map[string]int{"team-a": 1}, counts[btpOperatorServiceBinding])
+	assert.Equal(t, map[string]int{"team-a": 1}, counts[secretKind])
+}
+
+func TestBtpOperatorReconciler_AddCommonMetadata(t *testing.T) {
+	// given
+	btpOperator := createDefaultBtpOperator()
+	btpOperator.Spec.CommonLabels = map[string]string{"team": "platform", managedByLabelKey: "someone-else"}
+	btpOperator.Spec.CommonAnnotations = map[string]string{"owner": "platform@example.com"}
+
+	deployment := &unstructured.Unstructured{}
+	deployment.SetKind(deploymentKind)
+	deployment.SetName(DeploymentName)
+	deployment.SetLabels(map[string]string{managedByLabelKey: operatorName})
+	require.NoError(t, unstructured.SetNestedStringMap(deployment.Object, map[string]string{"app": "sap-btp-operator"}, "spec", "template", "metadata", "labels"))
+	crd := &unstructured.Unstructured{}
+	crd.SetKind(customResourceDefinitionKind)
+	crd.SetName("serviceinstances.services.cloud.sap.com")
+
+	btpOperatorReconciler := NewBtpOperatorReconciler(nil, nil, nil, nil, nil)
+
+	// when
+	err := btpOperatorReconciler.addCommonMetadata(btpOperator, deployment, crd)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{managedByLabelKey: operatorName, "team": "platform"}, deployment.GetLabels())
+	assert.Equal(t, map[string]string{"owner": "platform@example.com"}, deployment.GetAnnotations())
+	podLabels, _, err := unstructured.NestedStringMap(deployment.Object, "spec", "template", "metadata", "labels")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "sap-btp-operator", "team": "platform", managedByLabelKey: "someone-else"}, podLabels)
+	podAnnotations, _, err := unstructured.NestedStringMap(deployment.Object, "spec", "template", "metadata", "annotations")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "platform@example.com"}, podAnnotations)
+	assert.Equal(t, "platform", crd.GetLabels()["team"])
+	assert.Equal(t, "platform@example.com", crd.GetAnnotations()["owner"])
+}
+
+func TestBtpOperatorReconciler_ReconcileConfig(t *testing.T) {
+	originalApplyRetryCount := ApplyRetryCount
+	originalApplyTimeout := ApplyTimeout
+	originalLogLevel := LogLevel.Level()
+	originalLogFormat := LogFormat.String()
+	restoreOriginals := func() {
+		ApplyRetryCount = originalApplyRetryCount
+		ApplyTimeout = originalApplyTimeout
+		LogLevel.SetLevel(originalLogLevel)
+		require.NoError(t, LogFormat.Set(originalLogFormat))
+	}
+	ctx := context.Background()
+
+	t.Run("should restore a setting when its key is removed from the ConfigMap", func(t *testing.T) {
+		defer restoreOriginals()
+
+		// given
+		btpOperatorReconciler := NewBtpOperatorReconciler(nil, nil, nil, nil, nil)
+		btpOperatorReconciler.reconcileConfig(ctx, initConfig(map[string]string{"ApplyRetryCount": "7", "ApplyTimeout": "3m", "LogLevel": "2"}))
+		require.Equal(t, 7, ApplyRetryCount)
+		require.Equal(t, time.Minute*3, ApplyTimeout)
+
+		// when
+		btpOperatorReconciler.reconcileConfig(ctx, initConfig(map[string]string{"ApplyTimeout": "4m"}))
+
+		// then
+		assert.Equal(t, originalApplyRetryCount, ApplyRetryCount)
+		assert.Equal(t, originalLogLevel, LogLevel.Level())
+		assert.Equal(t, time.Minute*4, ApplyTimeout)
+	})
+
+	t.Run("should keep the current value when the new value is invalid", func(t *testing.T) {
+		defer restoreOriginals()
+
+		// given
+		btpOperatorReconciler := NewBtpOperatorReconciler(nil, nil, nil, nil, nil)
+		btpOperatorReconciler.reconcileConfig(ctx, initConfig(map[string]string{"ApplyTimeout": "3m"}))
+
+		// when
+		btpOperatorReconciler.reconcileConfig(ctx, initConfig(map[string]string{"ApplyTimeout": "soon"}))
+
+		// then
+		assert.Equal(t, time.Minute*3, ApplyTimeout)
+	})
+
+	t.Run("should restore all settings when the ConfigMap is deleted", func(t *testing.T) {
+		defer restoreOriginals()
+
+		// given
+		btpOperatorReconciler := NewBtpOperatorReconciler(nil, nil, nil, nil, nil)
+		cm := initConfig(map[string]string{"ApplyRetryCount": "7", "ApplyTimeout": "3m"})
+		btpOperatorReconciler.reconcileConfig(ctx, cm)
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer queue.ShutDown()
+
+		// when
+		btpOperatorReconciler.configEventHandler().Delete(ctx, event.DeleteEvent{Object: cm}, queue)
+
+		// then
+		assert.Equal(t, originalApplyRetryCount, ApplyRetryCount)
+		assert.Equal(t, originalApplyTimeout, ApplyTimeout)
+		assert.Equal(t, 1, queue.Len())
+	})
+
+	t.Run("should switch the log format and restore it", func(t *testing.T) {
+		defer restoreOriginals()
+
+		// given
+		btpOperatorReconciler := NewBtpOperatorReconciler(nil, nil, nil, nil, nil)
+		btpOperatorReconciler.reconcileConfig(ctx, initConfig(map[string]string{"LogFormat": "console"}))
+		require.Equal(t, logformat.Console, LogFormat.String())
+
+		// when
+		btpOperatorReconciler.reconcileConfig(ctx, initConfig(map[string]string{"LogFormat": "text"}))
+
+		// then
+		assert.Equal(t, logformat.Console, LogFormat.String())
+
+		// when
+		btpOperatorReconciler.reconcileConfig(ctx, initConfig(map[string]string{}))
+
+		// then
+		assert.Equal(t, originalLogFormat, LogFormat.String())
+	})
+
+	t.Run("should update the interval read by the metrics runnable", func(t *testing.T) {
+		originalResourceCountMetricsInterval := ResourceCountMetricsInterval
+		resourceCountMetricsInterval.Store(int64(originalResourceCountMetricsInterval))
+		defer func() {
+			ResourceCountMetricsInterval = originalResourceCountMetricsInterval
+			resourceCountMetricsInterval.Store(int64(originalResourceCountMetricsInterval))
+		}()
+
+		// given
+		btpOperatorReconciler := NewBtpOperatorReconciler(nil, nil, nil, nil, nil)
+		btpOperatorReconciler.reconcileConfig(ctx, initConfig(map[string]string{"ResourceCountMetricsInterval": "0s"}))
+		require.Equal(t, time.Duration(0), time.Duration(resourceCountMetricsInterval.Load()))
+
+		// when
+		btpOperatorReconciler.reconcileConfig(ctx, initConfig(map[string]string{}))
+
+		// then
+		assert.Equal(t, originalResourceCountMetricsInterval, ResourceCountMetricsInterval)
+		assert.Equal(t, originalResourceCountMetricsInterval, time.Duration(resourceCountMetricsInterval.Load()))
+	})
+}
+
+func TestBtpOperatorReconciler_CheckSecretReferences(t *testing.T) {
+	ctx := context.Background()
+	scheme := clientgoscheme.Scheme
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	newResourceWithSpec := func(gvk schema.GroupVersionKind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+		u := resourceFixture(gvk, namespace, name)
+		u.Object["spec"] = spec
+		return u
+	}
+	parametersFrom := func(secretName string) []interface{} {
+		return []interface{}{map[string]interface{}{"secretKeyRef": map[string]interface{}{"name": secretName, "key": "parameters"}}}
+	}
+	instance := newResourceWithSpec(instanceGvk, "team-a", "instance", map[string]interface{}{
+		"btpAccessCredentialsSecret": "team-a-credentials",
+		"parametersFrom":             parametersFrom("instance-parameters"),
+	})
+	binding := newResourceWithSpec(bindingGvk, "team-a", "binding", map[string]interface{}{
+		"parametersFrom": parametersFrom("binding-parameters"),
+	})
+	btpOperator := createDefaultBtpOperator()
+	bindingParameters := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "binding-parameters", Namespace: "team-a"}}
+
+	secretRequests := map[string]int{}
+	fakeK8sClient := newFakeClient(scheme, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if obj.GetObjectKind().GroupVersionKind().Kind == secretKind {
+				secretRequests["get"]++
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if list.GetObjectKind().GroupVersionKind().Kind == secretKind+"List" {
+				listOptions := &client.ListOptions{}
+				listOptions.ApplyOptions(opts)
+				secretRequests["list "+listOptions.Namespace]++
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}, newCrd(instanceGvk), newCrd(bindingGvk), instance, binding, bindingParameters, btpOperator)
+	btpOperatorReconciler := NewBtpOperatorReconciler(fakeK8sClient, fakeK8sClient, scheme, nil, nil)
+	btpOperatorReconciler.credentialsNamespaceFromSapBtpManagerSecret = kymaNamespace
+
+	t.Run("should report service instances referencing missing Secrets", func(t *testing.T) {
+		// when
+		err := btpOperatorReconciler.checkSecretReferences(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		condition := meta.FindStatusCondition(conditionsWithoutNil(btpOperator.Status.Conditions), conditions.SecretReferencesResolvedType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, string(conditions.MissingReferencedSecrets), condition.Reason)
+		assert.Equal(t, "ServiceInstance team-a/instance references missing Secret kyma-system/team-a-credentials; ServiceInstance team-a/instance references missing Secret team-a/instance-parameters", condition.Message)
+		assert.Equal(t, map[string]int{"list team-a": 1, "list " + kymaNamespace: 1}, secretRequests)
+	})
+
+	t.Run("should remove the condition when the BtpOperator leaves the Ready state", func(t *testing.T) {
+		// given
+		StatusUpdateTimeout = statusUpdateTimeout
+		StatusUpdateCheckInterval = statusUpdateCheckInterval
+		require.NotNil(t, meta.FindStatusCondition(conditionsWithoutNil(btpOperator.Status.Conditions), conditions.SecretReferencesResolvedType))
+
+		// when
+		err := btpOperatorReconciler.UpdateBtpOperatorStatus(ctx, btpOperator, v1alpha1.StateError, conditions.ReconcileFailed, "reconciliation failed")
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, meta.FindStatusCondition(conditionsWithoutNil(btpOperator.Status.Conditions), conditions.SecretReferencesResolvedType))
+		require.NoError(t, btpOperatorReconciler.checkSecretReferences(ctx, btpOperator))
+	})
+
+	t.Run("should remove the condition when all referenced Secrets exist", func(t *testing.T) {
+		// given
+		require.NoError(t, fakeK8sClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "team-a-credentials", Namespace: kymaNamespace}}))
+		require.NoError(t, fakeK8sClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "instance-parameters", Namespace: "team-a"}}))
+
+		// when
+		err := btpOperatorReconciler.checkSecretReferences(ctx, btpOperator)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, meta.FindStatusCondition(conditionsWithoutNil(btpOperator.Status.Conditions), conditions.SecretReferencesResolvedType))
+	})
+}
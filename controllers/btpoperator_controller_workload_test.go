@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/btp-manager/api/v1alpha1"
+	"github.com/kyma-project/btp-manager/internal/manifest"
+)
+
+var _ = Describe("BTP Operator controller - workload customization", Label("customization"), func() {
+	var (
+		reconciler       *BtpOperatorReconciler
+		btpOperator      *v1alpha1.BtpOperator
+		resourcesToApply []*unstructured.Unstructured
+		ctx              context.Context
+	)
+
+	findPodDisruptionBudget := func(us []*unstructured.Unstructured) *policyv1.PodDisruptionBudget {
+		for _, u := range us {
+			if u.GetKind() != podDisruptionBudgetKind {
+				continue
+			}
+			pdb := &policyv1.PodDisruptionBudget{}
+			Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, pdb)).To(Succeed())
+			return pdb
+		}
+		return nil
+	}
+
+	BeforeEach(func() {
+		GinkgoWriter.Println("--- PROCESS:", GinkgoParallelProcess(), "---")
+		ctx = context.Background()
+		reconciler = &BtpOperatorReconciler{
+			Client:          k8sClient,
+			Scheme:          k8sClient.Scheme(),
+			manifestHandler: &manifest.Handler{Scheme: k8sManager.GetScheme()},
+		}
+		btpOperator = createDefaultBtpOperator()
+
+		var err error
+		resourcesToApply, err = reconciler.createUnstructuredObjectsFromManifestsDir(reconciler.getResourcesToApplyPath())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("the replicas are set", func() {
+		It("should set the replicas and enable leader election for more than one replica", func() {
+			replicas := int32(3)
+			btpOperator.Spec.Replicas = &replicas
+
+			Expect(reconciler.prepareWorkloadCustomizations(ctx, btpOperator, &resourcesToApply)).To(Succeed())
+
+			deployment := reconciler.findSapBtpServiceOperatorDeployment(resourcesToApply)
+			Expect(deployment).NotTo(BeNil())
+			deploymentReplicas, found, err := unstructured.NestedInt64(deployment.Object, "spec", "replicas")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(deploymentReplicas).To(Equal(int64(3)))
+			Expect(getContainerArgs(deployment, sapBtpServiceOperatorContainerName)).To(ContainElement(enableLeaderElectionArg))
+		})
+
+		It("should not enable leader election for a single replica", func() {
+			replicas := int32(1)
+			btpOperator.Spec.Replicas = &replicas
+
+			Expect(reconciler.prepareWorkloadCustomizations(ctx, btpOperator, &resourcesToApply)).To(Succeed())
+
+			deployment := reconciler.findSapBtpServiceOperatorDeployment(resourcesToApply)
+			Expect(getContainerArgs(deployment, sapBtpServiceOperatorContainerName)).NotTo(ContainElement(enableLeaderElectionArg))
+		})
+
+		It("should add the leader election argument only once", func() {
+			replicas := int32(2)
+			btpOperator.Spec.Replicas = &replicas
+
+			Expect(reconciler.prepareWorkloadCustomizations(ctx, btpOperator, &resourcesToApply)).To(Succeed())
+			Expect(reconciler.prepareWorkloadCustomizations(ctx, btpOperator, &resourcesToApply)).To(Succeed())
+
+			deployment := reconciler.findSapBtpServiceOperatorDeployment(resourcesToApply)
+			args := getContainerArgs(deployment, sapBtpServiceOperatorContainerName)
+			count := 0
+			for _, arg := range args {
+				if arg == enableLeaderElectionArg {
+					count++
+				}
+			}
+			Expect(count).To(Equal(1))
+		})
+	})
+
+	When("the priority class name is set", func() {
+		It("should set the priority class in the SAP BTP service operator deployment", func() {
+			btpOperator.Spec.PriorityClassName = "custom-priority"
+
+			Expect(reconciler.prepareWorkloadCustomizations(ctx, btpOperator, &resourcesToApply)).To(Succeed())
+
+			deployment := reconciler.findSapBtpServiceOperatorDeployment(resourcesToApply)
+			Expect(deployment).NotTo(BeNil())
+			priorityClassName, found, err := unstructured.NestedString(deployment.Object, "spec", "template", "spec", "priorityClassName")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(priorityClassName).To(Equal("custom-priority"))
+		})
+	})
+
+	When("the pod disruption budget is set", func() {
+		It("should add the pod disruption budget with the deployment selector and default max unavailable", func() {
+			btpOperator.Spec.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{}
+
+			Expect(reconciler.prepareWorkloadCustomizations(ctx, btpOperator, &resourcesToApply)).To(Succeed())
+
+			pdb := findPodDisruptionBudget(resourcesToApply)
+			Expect(pdb).NotTo(BeNil())
+			Expect(pdb.Name).To(Equal(DeploymentName))
+			Expect(pdb.Spec.MinAvailable).To(BeNil())
+			Expect(pdb.Spec.MaxUnavailable).To(Equal(&intstr.IntOrString{Type: intstr.Int, IntVal: 1}))
+
+			deployment := reconciler.findSapBtpServiceOperatorDeployment(resourcesToApply)
+			selectorLabels, _, err := unstructured.NestedStringMap(deployment.Object, "spec", "selector", "matchLabels")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pdb.Spec.Selector.MatchLabels).To(Equal(selectorLabels))
+		})
+
+		It("should use max unavailable when configured", func() {
+			maxUnavailable := intstr.FromString("50%")
+			btpOperator.Spec.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}
+
+			Expect(reconciler.prepareWorkloadCustomizations(ctx, btpOperator, &resourcesToApply)).To(Succeed())
+
+			pdb := findPodDisruptionBudget(resourcesToApply)
+			Expect(pdb).NotTo(BeNil())
+			Expect(pdb.Spec.MinAvailable).To(BeNil())
+			Expect(pdb.Spec.MaxUnavailable).To(Equal(&maxUnavailable))
+		})
+	})
+
+	When("the pod disruption budget is not set", func() {
+		It("should delete the existing managed pod disruption budget", func() {
+			minAvailable := intstr.FromInt32(1)
+			existingPdb := &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      DeploymentName,
+					Namespace: kymaNamespace,
+					Labels:    map[string]string{managedByLabelKey: operatorName},
+				},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					MinAvailable: &minAvailable,
+					Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, existingPdb)).To(Succeed())
+
+			Expect(reconciler.prepareWorkloadCustomizations(ctx, btpOperator, &resourcesToApply)).To(Succeed())
+
+			Expect(findPodDisruptionBudget(resourcesToApply)).To(BeNil())
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(existingPdb), &policyv1.PodDisruptionBudget{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
+
+func getContainerArgs(deployment *unstructured.Unstructured, containerName string) []string {
+	containers, _, err := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	Expect(err).NotTo(HaveOccurred())
+	for _, c := range containers {
+		container := c.(map[string]interface{})
+		if container["name"] == containerName {
+			args, _, err := unstructured.NestedStringSlice(container, "args")
+			Expect(err).NotTo(HaveOccurred())
+			return args
+		}
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package controllers
 
-import "github.com/kyma-project/btp-manager/internal/conditions"
+import (
+	"errors"
+
+	"github.com/kyma-project/btp-manager/internal/conditions"
+)
 
 type ErrorWithReason struct {
 	message string
@@ -17,3 +21,11 @@ func NewErrorWithReason(reason conditions.Reason, message string) *ErrorWithReas
 func (e *ErrorWithReason) Error() string {
 	return e.message
 }
+
+func errorWithReasonFrom(err error) *ErrorWithReason {
+	var errWithReason *ErrorWithReason
+	if errors.As(err, &errWithReason) {
+		return errWithReason
+	}
+	return nil
+}
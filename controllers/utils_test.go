@@ -27,6 +27,7 @@ import (
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +41,8 @@ import (
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -437,6 +440,14 @@ func ensureResourceExists(gvk schema.GroupVersionKind) {
 }
 
 func createResource(gvk schema.GroupVersionKind, namespace string, name string) *unstructured.Unstructured {
+	object := resourceFixture(gvk, namespace, name)
+	Expect(k8sClient.Create(ctx, object)).To(BeNil())
+
+	return object
+}
+
+// resourceFixture returns the object created by createResource without creating it, for tests with the fake client
+func resourceFixture(gvk schema.GroupVersionKind, namespace string, name string) *unstructured.Unstructured {
 	object := &unstructured.Unstructured{}
 	object.SetGroupVersionKind(gvk)
 	object.SetNamespace(namespace)
@@ -447,21 +458,58 @@ func createResource(gvk schema.GroupVersionKind, namespace string, name string)
 	} else if kind == bindingGvk.Kind {
 		populateServiceBindingFields(object)
 	}
-	Expect(k8sClient.Create(ctx, object)).To(BeNil())
 
 	return object
 }
 
+func newCrd(gvk schema.GroupVersionKind) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%ss.%s", strings.ToLower(gvk.Kind), gvk.Group)}}
+}
+
+// newFakeClient builds a fake client with the interceptors and the objects, serving the status subresource of the BtpOperators among them
+func newFakeClient(scheme *runtime.Scheme, funcs interceptor.Funcs, objects ...client.Object) client.Client {
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(funcs)
+	for _, object := range objects {
+		if _, ok := object.(*v1alpha1.BtpOperator); ok {
+			builder = builder.WithStatusSubresource(object)
+		}
+	}
+	return builder.Build()
+}
+
+// newDeployment returns the SAP BTP service operator Deployment labelled with the chart version
+func newDeployment(chartVersion string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("apps/v1")
+	u.SetKind(deploymentKind)
+	u.SetName(DeploymentName)
+	u.SetNamespace(ChartNamespace)
+	u.SetLabels(map[string]string{chartVersionKey: chartVersion})
+	return u
+}
+
+func newSecret(namespace, name string, ownerReferences ...metav1.OwnerReference) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, OwnerReferences: ownerReferences}}
+}
+
+func newBindingOwnerReference(binding *unstructured.Unstructured) metav1.OwnerReference {
+	return metav1.OwnerReference{APIVersion: bindingGvk.GroupVersion().String(), Kind: btpOperatorServiceBinding, Name: binding.GetName(), UID: binding.GetUID()}
+}
+
 func populateServiceInstanceFields(object *unstructured.Unstructured) {
-	Expect(unstructured.SetNestedField(object.Object, "test-service", "spec", "serviceOfferingName")).To(Succeed())
-	Expect(unstructured.SetNestedField(object.Object, "test-plan", "spec", "servicePlanName")).To(Succeed())
-	Expect(unstructured.SetNestedField(object.Object, "test-service-instance-external", "spec", "externalName")).To(Succeed())
+	object.Object["spec"] = map[string]interface{}{
+		"serviceOfferingName": "test-service",
+		"servicePlanName":     "test-plan",
+		"externalName":        "test-service-instance-external",
+	}
 }
 
 func populateServiceBindingFields(object *unstructured.Unstructured) {
-	Expect(unstructured.SetNestedField(object.Object, "test-service-instance", "spec", "serviceInstanceName")).To(Succeed())
-	Expect(unstructured.SetNestedField(object.Object, "test-binding-external", "spec", "externalName")).To(Succeed())
-	Expect(unstructured.SetNestedField(object.Object, "test-service-binding-secret", "spec", "secretName")).To(Succeed())
+	object.Object["spec"] = map[string]interface{}{
+		"serviceInstanceName": "test-service-instance",
+		"externalName":        "test-binding-external",
+		"secretName":          "test-service-binding-secret",
+	}
 }
 
 func filterWebhooks(file []byte) (filtered []byte, hasWebhook bool) {
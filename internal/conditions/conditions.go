@@ -40,17 +40,25 @@ const (
 	ClusterIdChanged                                  Reason = "ClusterIdChanged"
 	AnnotatingSecretFailed                            Reason = "AnnotatingSecretFailed"
 	GettingSapBtpServiceOperatorClusterIdSecretFailed Reason = "GettingSapBtpServiceOperatorClusterIdSecretFailed"
+	ForceDeleteConfirmationRequired                   Reason = "ForceDeleteConfirmationRequired"
+	UpgradeApprovalRequired                           Reason = "UpgradeApprovalRequired"
+	UpgradeDryRunFailed                               Reason = "UpgradeDryRunFailed"
+	MissingReferencedSecrets                          Reason = "MissingReferencedSecrets"
 )
 
 // gophers_reasons_section_end
 
 const (
-	ReadyType = "Ready"
+	ReadyType                    = "Ready"
+	SecretReferencesResolvedType = "SecretReferencesResolved"
 )
 
+// Metadata describes the condition set for a reason. Reasons without Type set the Ready condition and the State,
+// other reasons set a condition of their own type, which doesn't change the BtpOperator state
 type Metadata struct {
 	Status metav1.ConditionStatus
 	State  v1alpha1.State
+	Type   string
 }
 
 // gophers_metadata_section_start
@@ -85,6 +93,12 @@ var Reasons = map[Reason]Metadata{
 	CredentialsNamespaceChanged:                       {Status: metav1.ConditionFalse, State: v1alpha1.StateProcessing}, //Processing;Credentials namespace changed
 	ClusterIdChanged:                                  {Status: metav1.ConditionFalse, State: v1alpha1.StateProcessing}, //Processing;Cluster ID changed
 	GettingSapBtpServiceOperatorClusterIdSecretFailed: {Status: metav1.ConditionFalse, State: v1alpha1.StateError},      //Error;Getting SAP BTP service operator Cluster ID Secret failed
+	ForceDeleteConfirmationRequired:                   {Status: metav1.ConditionFalse, State: v1alpha1.StateWarning},    //Warning;Force delete requires confirmation because of the number of affected resources
+	UpgradeApprovalRequired:                           {Status: metav1.ConditionFalse, State: v1alpha1.StateWarning},    //Warning;Upgrade of the module resources waits for approval - review the upgrade check findings
+	UpgradeDryRunFailed:                               {Status: metav1.ConditionFalse, State: v1alpha1.StateError},      //Error;Server-side dry run of the new module version failed, the live resources were not upgraded
+
+	// reasons of conditions other than Ready, which don't change the BtpOperator state
+	MissingReferencedSecrets: {Status: metav1.ConditionFalse, Type: SecretReferencesResolvedType}, //NA;ServiceInstances or ServiceBindings reference Secrets that don't exist;SecretReferencesResolved
 }
 
 // gophers_metadata_section_end
@@ -92,11 +106,15 @@ var Reasons = map[Reason]Metadata{
 func ConditionFromExistingReason(reason Reason, message string) *metav1.Condition {
 	metadata, found := Reasons[reason]
 	if found {
+		conditionType := metadata.Type
+		if conditionType == "" {
+			conditionType = ReadyType
+		}
 		return &metav1.Condition{
 			Status:             metadata.Status,
 			Reason:             string(reason),
 			Message:            message,
-			Type:               ReadyType,
+			Type:               conditionType,
 			ObservedGeneration: 0,
 		}
 	}
@@ -122,3 +140,14 @@ func SetStatusCondition(conditions *[]*metav1.Condition, newCondition metav1.Con
 		(*conditions)[conditionsCnt] = &conditionsArray[conditionsCnt]
 	}
 }
+
+// RemoveStatusCondition removes the condition of the given type and reports whether it was present
+func RemoveStatusCondition(conditions *[]*metav1.Condition, conditionType string) bool {
+	for i, c := range *conditions {
+		if c != nil && c.Type == conditionType {
+			*conditions = append((*conditions)[:i], (*conditions)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -23,6 +23,12 @@ func TestNewConditionForReason(t *testing.T) {
 		assert.Equal(t, "Your resource must be in the kyma-system namespace. The resource's name must be btpoperator.", condition.Message)
 		assert.Equal(t, "WrongNamespaceOrName", condition.Reason)
 	})
+	t.Run("should create new condition of the type of the Reason", func(t *testing.T) {
+		condition := ConditionFromExistingReason("MissingReferencedSecrets", "ServiceInstance team-a/instance references missing Secret team-a/parameters")
+		assert.Equal(t, "SecretReferencesResolved", condition.Type)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "MissingReferencedSecrets", condition.Reason)
+	})
 	t.Run("should not create new condition for not predefined Reason", func(t *testing.T) {
 		condition := ConditionFromExistingReason("non-existing-reason", "Ready to process")
 		assert.Nil(t, condition)
@@ -69,3 +75,24 @@ func TestSetStatusCondition(t *testing.T) {
 		assert.Equal(t, "MissingSecret", btpOperator.Status.Conditions[0].Reason)
 	})
 }
+
+func TestRemoveStatusCondition(t *testing.T) {
+	t.Run("should remove only the condition of the given type", func(t *testing.T) {
+		btpOperator := &v1alpha1.BtpOperator{}
+		SetStatusCondition(&btpOperator.Status.Conditions, *ConditionFromExistingReason("ReconcileSucceeded", "Ready to process"))
+		SetStatusCondition(&btpOperator.Status.Conditions, metav1.Condition{Type: SecretReferencesResolvedType, Status: metav1.ConditionFalse, Reason: string(MissingReferencedSecrets)})
+
+		removed := RemoveStatusCondition(&btpOperator.Status.Conditions, SecretReferencesResolvedType)
+
+		assert.True(t, removed)
+		assert.Equal(t, 1, len(btpOperator.Status.Conditions))
+		assert.Equal(t, "Ready", btpOperator.Status.Conditions[0].Type)
+	})
+	t.Run("should report a missing condition", func(t *testing.T) {
+		btpOperator := &v1alpha1.BtpOperator{}
+
+		removed := RemoveStatusCondition(&btpOperator.Status.Conditions, SecretReferencesResolvedType)
+
+		assert.False(t, removed)
+	})
+}
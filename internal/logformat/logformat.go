@@ -0,0 +1,85 @@
+package logformat
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	JSON    = "json"
+	Console = "console"
+)
+
+// Format is the log encoding, which can be switched at runtime
+type Format struct {
+	value atomic.Value
+}
+
+func NewFormat(format string) *Format {
+	f := &Format{}
+	f.value.Store(format)
+	return f
+}
+
+// Set accepts the same values as the zap-encoder flag
+func (f *Format) Set(format string) error {
+	format = strings.ToLower(format)
+	if format != JSON && format != Console {
+		return fmt.Errorf("invalid log format %q, expected %s or %s", format, JSON, Console)
+	}
+	f.value.Store(format)
+	return nil
+}
+
+func (f *Format) String() string {
+	return f.value.Load().(string)
+}
+
+// switchingCore writes every entry with the core of the format set at the time of writing.
+// Both cores share the fields added with With, so switching the format keeps the logger names and values.
+type switchingCore struct {
+	format  *Format
+	json    zapcore.Core
+	console zapcore.Core
+}
+
+// NewCore returns a core that writes the entries with the json core or the console core, depending on the current format
+func NewCore(format *Format, json, console zapcore.Core) zapcore.Core {
+	return &switchingCore{format: format, json: json, console: console}
+}
+
+func (c *switchingCore) current() zapcore.Core {
+	if c.format.String() == Console {
+		return c.console
+	}
+	return c.json
+}
+
+func (c *switchingCore) Enabled(level zapcore.Level) bool {
+	return c.current().Enabled(level)
+}
+
+func (c *switchingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &switchingCore{format: c.format, json: c.json.With(fields), console: c.console.With(fields)}
+}
+
+func (c *switchingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *switchingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.current().Write(entry, fields)
+}
+
+func (c *switchingCore) Sync() error {
+	if err := c.json.Sync(); err != nil {
+		return err
+	}
+	return c.console.Sync()
+}
@@ -0,0 +1,72 @@
+package logformat
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFormat_Set(t *testing.T) {
+	// given
+	format := NewFormat(JSON)
+
+	// when
+	err := format.Set("Console")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Console, format.String())
+
+	// when
+	err = format.Set("text")
+
+	// then
+	require.Error(t, err)
+	assert.Equal(t, Console, format.String())
+}
+
+func TestNewCore(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	sink := zapcore.AddSync(out)
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = ""
+	format := NewFormat(JSON)
+	core := NewCore(format,
+		zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), sink, zapcore.InfoLevel),
+		zapcore.NewCore(zapcore.NewConsoleEncoder(encoderConfig), sink, zapcore.InfoLevel))
+	logger := zap.New(core).Named("config").With(zap.String("name", "sap-btp-manager"))
+
+	t.Run("should write JSON entries", func(t *testing.T) {
+		// when
+		logger.Info("reconciling config update")
+
+		// then
+		assert.JSONEq(t, `{"level":"info","logger":"config","msg":"reconciling config update","name":"sap-btp-manager"}`, out.String())
+		out.Reset()
+	})
+
+	t.Run("should switch to console entries with the same attributes", func(t *testing.T) {
+		// given
+		require.NoError(t, format.Set(Console))
+
+		// when
+		logger.Info("reconciling config update")
+
+		// then
+		assert.Equal(t, "info\tconfig\treconciling config update\t{\"name\": \"sap-btp-manager\"}\n", out.String())
+		out.Reset()
+	})
+
+	t.Run("should skip entries below the level", func(t *testing.T) {
+		// when
+		logger.Debug("debug entry")
+
+		// then
+		assert.Empty(t, out.String())
+	})
+}
@@ -11,6 +11,9 @@ const (
 
 type Metrics struct {
 	certsRegenerationsCounter prometheus.Counter
+	stuckResourcesGauge       *prometheus.GaugeVec
+	managedResourcesGauge     *prometheus.GaugeVec
+	strictTLSGauge            prometheus.Gauge
 }
 
 func (m *Metrics) registerMetrics() {
@@ -23,12 +26,58 @@ func (m *Metrics) registerMetrics() {
 	})
 	m.certsRegenerationsCounter = certRegenCounter
 	metrics.Registry.MustRegister(certRegenCounter)
+
+	stuckResourcesGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: buildMetricName("", "stuck_resources"),
+		Help: "Number of service instances and bindings stuck in deletion",
+	}, []string{"kind"})
+	m.stuckResourcesGauge = stuckResourcesGauge
+	metrics.Registry.MustRegister(stuckResourcesGauge)
+
+	managedResourcesGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: buildMetricName("", "managed_resources"),
+		Help: "Number of service instances, service bindings and service binding Secrets per namespace",
+	}, []string{"kind", "namespace"})
+	m.managedResourcesGauge = managedResourcesGauge
+	metrics.Registry.MustRegister(managedResourcesGauge)
+
+	strictTLSGauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: buildMetricName("", "strict_tls_enabled"),
+		Help: "Whether the connections to the API server are restricted to TLS 1.2 or newer with FIPS-approved cipher suites",
+	})
+	m.strictTLSGauge = strictTLSGauge
+	metrics.Registry.MustRegister(strictTLSGauge)
 }
 
 func (m *Metrics) IncreaseCertsRegenerationsCounter() {
 	m.certsRegenerationsCounter.Inc()
 }
 
+func (m *Metrics) SetStuckResources(kind string, count int) {
+	m.stuckResourcesGauge.WithLabelValues(kind).Set(float64(count))
+}
+
+// SetManagedResources replaces the per-namespace counts of the given kind, so that namespaces without resources are no longer reported
+func (m *Metrics) SetManagedResources(kind string, countsByNamespace map[string]int) {
+	m.managedResourcesGauge.DeletePartialMatch(prometheus.Labels{"kind": kind})
+	for namespace, count := range countsByNamespace {
+		m.managedResourcesGauge.WithLabelValues(kind, namespace).Set(float64(count))
+	}
+}
+
+func (m *Metrics) SetStrictTLS(enabled bool) {
+	if enabled {
+		m.strictTLSGauge.Set(1)
+	} else {
+		m.strictTLSGauge.Set(0)
+	}
+}
+
+// ResetManagedResources removes the counts of all kinds and namespaces
+func (m *Metrics) ResetManagedResources() {
+	m.managedResourcesGauge.Reset()
+}
+
 func NewMetrics() *Metrics {
 	metrics := &Metrics{}
 	metrics.registerMetrics()
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_ManagedResources(t *testing.T) {
+	// given
+	m := NewMetrics()
+	m.SetManagedResources("ServiceInstance", map[string]int{"team-a": 2, "team-b": 1})
+	m.SetManagedResources("ServiceBinding", map[string]int{"team-a": 1})
+	assert.Equal(t, 3, testutil.CollectAndCount(m.managedResourcesGauge))
+
+	// when
+	m.SetManagedResources("ServiceInstance", map[string]int{"team-a": 3})
+
+	// then
+	assert.Equal(t, 2, testutil.CollectAndCount(m.managedResourcesGauge))
+	assert.Equal(t, float64(3), testutil.ToFloat64(m.managedResourcesGauge.WithLabelValues("ServiceInstance", "team-a")))
+
+	// when
+	m.ResetManagedResources()
+
+	// then
+	assert.Equal(t, 0, testutil.CollectAndCount(m.managedResourcesGauge))
+}
+
+func TestMetrics_StrictTLS(t *testing.T) {
+	// given
+	m := &Metrics{strictTLSGauge: prometheus.NewGauge(prometheus.GaugeOpts{Name: "strict_tls_enabled"})}
+
+	// when
+	m.SetStrictTLS(true)
+
+	// then
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.strictTLSGauge))
+
+	// when
+	m.SetStrictTLS(false)
+
+	// then
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.strictTLSGauge))
+}
@@ -0,0 +1,87 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// StrictMinVersion is the lowest TLS version accepted in the strict mode
+const StrictMinVersion = tls.VersionTLS12
+
+// StrictCipherSuites are the FIPS-approved AEAD cipher suites accepted for TLS 1.2 in the strict mode.
+// TLS 1.3 cipher suites are not configurable in crypto/tls and are all FIPS-approved.
+var StrictCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// Strict limits the protocol versions to TLS 1.2 and newer and the TLS 1.2 cipher suites to StrictCipherSuites
+func Strict(cfg *tls.Config) {
+	cfg.MinVersion = StrictMinVersion
+	cfg.CipherSuites = append([]uint16{}, StrictCipherSuites...)
+}
+
+// StrictTransport applies Strict to a copy of the client-go HTTP transport, so it can be set as the WrapTransport of a rest.Config.
+// Other round trippers are returned unchanged, because client-go builds an *http.Transport unless the rest.Config has a custom one.
+func StrictTransport(rt http.RoundTripper) http.RoundTripper {
+	transport, ok := rt.(*http.Transport)
+	if !ok {
+		return rt
+	}
+	strict := transport.Clone()
+	if strict.TLSClientConfig == nil {
+		strict.TLSClientConfig = &tls.Config{}
+	}
+	Strict(strict.TLSClientConfig)
+	return strict
+}
+
+// ValidateStrict checks that every cipher suite of the strict mode is supported by crypto/tls, is not insecure and can be used with TLS 1.2
+func ValidateStrict() error {
+	supported := make(map[uint16]*tls.CipherSuite)
+	for _, suite := range tls.CipherSuites() {
+		supported[suite.ID] = suite
+	}
+	insecure := make(map[uint16]bool)
+	for _, suite := range tls.InsecureCipherSuites() {
+		insecure[suite.ID] = true
+	}
+
+	for _, id := range StrictCipherSuites {
+		name := tls.CipherSuiteName(id)
+		if insecure[id] {
+			return fmt.Errorf("cipher suite %s is insecure", name)
+		}
+		suite, found := supported[id]
+		if !found {
+			return fmt.Errorf("cipher suite %s is not supported", name)
+		}
+		if !supportsVersion(suite, StrictMinVersion) {
+			return fmt.Errorf("cipher suite %s does not support %s", name, tls.VersionName(StrictMinVersion))
+		}
+	}
+
+	return nil
+}
+
+// DescribeStrict returns the minimum TLS version and the cipher suites of the strict mode for logging
+func DescribeStrict() string {
+	names := make([]string, 0, len(StrictCipherSuites))
+	for _, id := range StrictCipherSuites {
+		names = append(names, tls.CipherSuiteName(id))
+	}
+	return fmt.Sprintf("minimum version %s, TLS 1.2 cipher suites %s", tls.VersionName(StrictMinVersion), strings.Join(names, ", "))
+}
+
+func supportsVersion(suite *tls.CipherSuite, version uint16) bool {
+	for _, v := range suite.SupportedVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrict(t *testing.T) {
+	// given
+	cfg := &tls.Config{MinVersion: tls.VersionTLS10, CipherSuites: []uint16{tls.TLS_RSA_WITH_AES_128_CBC_SHA}}
+
+	// when
+	Strict(cfg)
+
+	// then
+	assert.Equal(t, uint16(tls.VersionTLS12), cfg.MinVersion)
+	assert.Equal(t, StrictCipherSuites, cfg.CipherSuites)
+}
+
+func TestValidateStrict(t *testing.T) {
+	t.Run("should accept the strict cipher suites", func(t *testing.T) {
+		assert.NoError(t, ValidateStrict())
+	})
+
+	t.Run("should reject an insecure cipher suite", func(t *testing.T) {
+		// given
+		original := StrictCipherSuites
+		defer func() { StrictCipherSuites = original }()
+		StrictCipherSuites = append([]uint16{}, original...)
+		StrictCipherSuites = append(StrictCipherSuites, tls.TLS_RSA_WITH_RC4_128_SHA)
+
+		// when
+		err := ValidateStrict()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TLS_RSA_WITH_RC4_128_SHA is insecure")
+	})
+
+	t.Run("should reject a cipher suite that is not available for TLS 1.2", func(t *testing.T) {
+		// given
+		original := StrictCipherSuites
+		defer func() { StrictCipherSuites = original }()
+		StrictCipherSuites = []uint16{tls.TLS_AES_128_GCM_SHA256}
+
+		// when
+		err := ValidateStrict()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TLS_AES_128_GCM_SHA256 does not support TLS 1.2")
+	})
+}
+
+func TestStrictHandshake(t *testing.T) {
+	// given
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{}
+	Strict(server.TLS)
+	server.StartTLS()
+	defer server.Close()
+
+	dial := func(cfg *tls.Config) error {
+		cfg.InsecureSkipVerify = true
+		conn, err := tls.DialWithDialer(&net.Dialer{}, "tcp", server.Listener.Addr().String(), cfg)
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	t.Run("should accept a TLS 1.2 client with a strict cipher suite", func(t *testing.T) {
+		assert.NoError(t, dial(&tls.Config{MaxVersion: tls.VersionTLS12, CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}}))
+	})
+
+	t.Run("should reject a TLS 1.1 client", func(t *testing.T) {
+		assert.Error(t, dial(&tls.Config{MinVersion: tls.VersionTLS10, MaxVersion: tls.VersionTLS11}))
+	})
+
+	t.Run("should reject a TLS 1.2 client without a strict cipher suite", func(t *testing.T) {
+		assert.Error(t, dial(&tls.Config{MaxVersion: tls.VersionTLS12, CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA}}))
+	})
+}
+
+func TestStrictTransport(t *testing.T) {
+	newServer := func(cfg *tls.Config) *httptest.Server {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.TLS = cfg
+		server.StartTLS()
+		return server
+	}
+	get := func(rt http.RoundTripper, url string) error {
+		resp, err := (&http.Client{Transport: rt}).Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	t.Run("should connect to a server with a strict cipher suite", func(t *testing.T) {
+		// given
+		server := newServer(&tls.Config{MaxVersion: tls.VersionTLS12, CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}})
+		defer server.Close()
+		transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+
+		// when
+		rt := StrictTransport(transport)
+
+		// then
+		assert.NoError(t, get(rt, server.URL))
+		assert.Empty(t, transport.TLSClientConfig.CipherSuites)
+	})
+
+	t.Run("should not connect to a server without a strict cipher suite", func(t *testing.T) {
+		// given
+		server := newServer(&tls.Config{MaxVersion: tls.VersionTLS12, CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA}})
+		defer server.Close()
+		transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+
+		// when
+		rt := StrictTransport(transport)
+
+		// then
+		assert.Error(t, get(rt, server.URL))
+		assert.NoError(t, get(transport, server.URL))
+	})
+
+	t.Run("should return other round trippers unchanged", func(t *testing.T) {
+		// given
+		rt := http.RoundTripper(roundTripperFunc(func(r *http.Request) (*http.Response, error) { return nil, nil }))
+
+		// when
+		wrapped := StrictTransport(rt)
+
+		// then
+		assert.NotNil(t, wrapped)
+		_, isTransport := wrapped.(*http.Transport)
+		assert.False(t, isTransport)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
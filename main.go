@@ -26,6 +26,8 @@ import (
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,7 +41,9 @@ import (
 
 	"github.com/kyma-project/btp-manager/api/v1alpha1"
 	"github.com/kyma-project/btp-manager/controllers"
+	"github.com/kyma-project/btp-manager/internal/logformat"
 	btpmanagermetrics "github.com/kyma-project/btp-manager/internal/metrics"
+	"github.com/kyma-project/btp-manager/internal/tlsconfig"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -60,11 +64,14 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var strictTLS bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&strictTLS, "strict-tls", false,
+		"Restrict the connections to the API server to TLS 1.2 or newer with FIPS-approved cipher suites.")
 	flag.StringVar(&controllers.ChartNamespace, "chart-namespace", controllers.ChartNamespace, "Namespace to install chart resources.")
 	flag.StringVar(&controllers.SecretName, "secret-name", controllers.SecretName, "Secret name with input values for sap-btp-operator chart templating.")
 	flag.StringVar(&controllers.ConfigName, "config-name", controllers.ConfigName, "ConfigMap name with configuration knobs for the btp-manager internals.")
@@ -78,7 +85,17 @@ func main() {
 	flag.DurationVar(&controllers.HardDeleteCheckInterval, "hard-delete-check-interval", controllers.HardDeleteCheckInterval, "Hard delete retry interval.")
 	flag.DurationVar(&controllers.HardDeleteTimeout, "hard-delete-timeout", controllers.HardDeleteTimeout, "Hard delete timeout.")
 	flag.DurationVar(&controllers.DeleteRequestTimeout, "delete-request-timeout", controllers.DeleteRequestTimeout, "Delete request timeout in hard delete.")
+	flag.DurationVar(&controllers.ApplyTimeout, "apply-timeout", controllers.ApplyTimeout, "Timeout for a single module resource apply or update request.")
+	flag.IntVar(&controllers.ApplyRetryCount, "apply-retry-count", controllers.ApplyRetryCount, "Number of retries of a module resource apply or update after a transient error.")
+	flag.DurationVar(&controllers.ApplyRetryInterval, "apply-retry-interval", controllers.ApplyRetryInterval, "Interval between module resource apply or update retries.")
+	flag.DurationVar(&controllers.ErrorStateRequeueInterval, "error-state-requeue-interval", controllers.ErrorStateRequeueInterval, `Minimal time in state "error" before the reconciliation is retried. Zero retries immediately.`)
+	flag.Var(&controllers.ErrorStateRequeueIntervals, "error-state-requeue-intervals", `Comma-separated list of Reason=duration pairs overriding -error-state-requeue-interval for the given reasons of state "error", for example ProvisioningFailed=5m.`)
+	flag.IntVar(&controllers.ForceDeleteConfirmationThreshold, "force-delete-confirmation-threshold", controllers.ForceDeleteConfirmationThreshold, "Number of service instances and bindings above which the force delete requires a confirmation annotation. Zero disables the confirmation.")
+	flag.DurationVar(&controllers.StuckDeletionThreshold, "stuck-deletion-threshold", controllers.StuckDeletionThreshold, "Time after which a service instance or binding in deletion is reported as stuck.")
+	flag.DurationVar(&controllers.ResourceCountMetricsInterval, "resource-count-metrics-interval", controllers.ResourceCountMetricsInterval, "Refresh interval of the service instances, bindings and binding Secrets count metrics. Zero disables the metrics.")
 	flag.StringVar(&controllers.EnableLimitedCache, "enable-limited-cache", controllers.EnableLimitedCache, "Enable limited cache for sap-btp-operator.")
+	flag.StringVar(&controllers.EnableWebhookReadinessCheck, "enable-webhook-readiness-check", controllers.EnableWebhookReadinessCheck, "Check the TLS handshake with the sap-btp-operator webhook server before reporting readiness.")
+	flag.StringVar(&controllers.EnableUpgradeDryRun, "enable-upgrade-dry-run", controllers.EnableUpgradeDryRun, "Apply a new version of the module resources with the server-side dry run against the live resources before upgrading them.")
 	opts := zap.Options{
 		Development: false,
 	}
@@ -94,9 +111,33 @@ func main() {
 		os.Exit(1)
 	}
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	if opts.Level != nil {
+		if level, ok := opts.Level.(uberzap.AtomicLevel); ok {
+			controllers.LogLevel.SetLevel(level.Level())
+		}
+	} else if opts.Development {
+		controllers.LogLevel.SetLevel(zapcore.DebugLevel)
+	}
+	opts.Level = controllers.LogLevel
+	if err := controllers.LogFormat.Set(initialLogFormat(&opts)); err != nil {
+		setupLog.Error(err, "invalid log format")
+		os.Exit(1)
+	}
+	logCore := logformat.NewCore(controllers.LogFormat, newLogCore(&opts, logformat.JSON), newLogCore(&opts, logformat.Console))
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts), zap.RawZapOpts(uberzap.WrapCore(func(zapcore.Core) zapcore.Core { return logCore }))))
+
+	if strictTLS {
+		if err := tlsconfig.ValidateStrict(); err != nil {
+			setupLog.Error(err, "invalid strict TLS configuration")
+			os.Exit(1)
+		}
+		setupLog.Info("strict TLS enabled for the API server connections", "tls", tlsconfig.DescribeStrict())
+	}
 
 	restCfg := ctrl.GetConfigOrDie()
+	if strictTLS {
+		restCfg.WrapTransport = tlsconfig.StrictTransport
+	}
 	mgr, err := ctrl.NewManager(restCfg, ctrl.Options{
 		Scheme:                 scheme,
 		LeaderElection:         enableLeaderElection,
@@ -118,6 +159,7 @@ func main() {
 
 	signalContext := ctrl.SetupSignalHandler()
 	metrics := btpmanagermetrics.NewMetrics()
+	metrics.SetStrictTLS(strictTLS)
 	cleanupReconciler := controllers.NewInstanceBindingControllerManager(signalContext, mgr.GetClient(), mgr.GetScheme(), restCfg)
 	reconciler := controllers.NewBtpOperatorReconciler(mgr.GetClient(), apiServerClient, scheme, cleanupReconciler, metrics)
 
@@ -156,3 +198,44 @@ func ensureRequiredEnvs(envs ...string) error {
 
 	return nil
 }
+
+// initialLogFormat returns the format set with the zap-encoder flag, or the default format of the zap-devel mode
+func initialLogFormat(opts *zap.Options) string {
+	if encoder := flag.Lookup("zap-encoder"); encoder != nil && encoder.Value.String() != "" {
+		return encoder.Value.String()
+	}
+	if opts.Development {
+		return logformat.Console
+	}
+	return logformat.JSON
+}
+
+// newLogCore builds the core of the given format the same way as controller-runtime builds it from the zap flags,
+// so that the LogFormat ConfigMap key can switch between the formats at runtime
+func newLogCore(opts *zap.Options, format string) zapcore.Core {
+	var encoderConfig zapcore.EncoderConfig
+	if format == logformat.Console {
+		encoderConfig = uberzap.NewDevelopmentEncoderConfig()
+	} else {
+		encoderConfig = uberzap.NewProductionEncoderConfig()
+	}
+	encoderConfig.EncodeTime = zapcore.RFC3339TimeEncoder
+	if opts.TimeEncoder != nil {
+		encoderConfig.EncodeTime = opts.TimeEncoder
+	}
+	for _, configure := range opts.EncoderConfigOptions {
+		configure(&encoderConfig)
+	}
+
+	var encoder zapcore.Encoder
+	if format == logformat.Console {
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	} else {
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	}
+	destination := opts.DestWriter
+	if destination == nil {
+		destination = os.Stderr
+	}
+	return zapcore.NewCore(&zap.KubeAwareEncoder{Encoder: encoder, Verbose: opts.Development}, zapcore.AddSync(destination), opts.Level)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/kyma-project/btp-manager/internal/logformat"
+)
+
+func TestInitialLogFormat(t *testing.T) {
+	assert.Equal(t, logformat.JSON, initialLogFormat(&zap.Options{}))
+	assert.Equal(t, logformat.Console, initialLogFormat(&zap.Options{Development: true}))
+}
+
+func TestNewLogCore(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	opts := &zap.Options{
+		DestWriter: out,
+		Level:      uberzap.NewAtomicLevelAt(zapcore.InfoLevel),
+		EncoderConfigOptions: []zap.EncoderConfigOption{func(config *zapcore.EncoderConfig) {
+			config.TimeKey = ""
+		}},
+	}
+	format := logformat.NewFormat(logformat.JSON)
+	logger := uberzap.New(logformat.NewCore(format, newLogCore(opts, logformat.JSON), newLogCore(opts, logformat.Console))).Named("setup")
+
+	// when
+	logger.Info("starting manager")
+	require.NoError(t, format.Set(logformat.Console))
+	logger.Info("starting manager")
+	logger.Debug("skipped")
+
+	// then
+	assert.Equal(t, "{\"level\":\"info\",\"logger\":\"setup\",\"msg\":\"starting manager\"}\nINFO\tsetup\tstarting manager\n", out.String())
+}